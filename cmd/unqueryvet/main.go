@@ -1,11 +1,65 @@
 package main
 
 import (
+	"fmt"
+	"os"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/singlechecker"
 
 	internal "github.com/MirrexOne/unqueryvet/internal/analyzer"
+	"github.com/MirrexOne/unqueryvet/pkg/config"
 )
 
 func main() {
-	singlechecker.Main(internal.NewAnalyzer())
+	singlechecker.Main(newAnalyzer())
+}
+
+// newAnalyzer returns the analyzer with a flag for every setting and a
+// -config flag. Settings are read once, from the file given with -config or
+// the nearest .unqueryvet.yml or .unqueryvet.json above the working
+// directory, and flags given on the command line override the file.
+func newAnalyzer() *analysis.Analyzer {
+	a := internal.NewAnalyzer()
+
+	var configPath string
+	a.Flags.StringVar(&configPath, "config", "", "path to a .unqueryvet.yml or .unqueryvet.json file (default: searched upward from the working directory)")
+	flagSettings := config.DefaultSettings()
+	flagSettings.RegisterFlags(&a.Flags)
+
+	var (
+		once     sync.Once
+		settings config.UnqueryvetSettings
+		err      error
+	)
+	a.Run = func(pass *analysis.Pass) (any, error) {
+		once.Do(func() {
+			settings, err = loadSettings(configPath)
+			settings.ApplyFlags(&a.Flags, &flagSettings)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return internal.RunWithConfig(pass, &settings)
+	}
+	return a
+}
+
+// loadSettings reads the configuration file at path, or the one found from
+// the working directory when path is empty. Without a file, the default
+// settings are used.
+func loadSettings(path string) (config.UnqueryvetSettings, error) {
+	if path == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return config.DefaultSettings(), fmt.Errorf("finding configuration file: %w", err)
+		}
+		found, ok := config.FindFile(wd)
+		if !ok {
+			return config.DefaultSettings(), nil
+		}
+		path = found
+	}
+	return config.LoadFile(path)
 }
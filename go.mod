@@ -1,10 +1,13 @@
 module github.com/MirrexOne/unqueryvet
 
-go 1.24.0
+go 1.25.0
 
-require golang.org/x/tools v0.36.0
+require (
+	golang.org/x/tools v0.44.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
-	golang.org/x/mod v0.27.0 // indirect
-	golang.org/x/sync v0.16.0 // indirect
+	golang.org/x/mod v0.35.0 // indirect
+	golang.org/x/sync v0.20.0 // indirect
 )
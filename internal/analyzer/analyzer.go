@@ -2,14 +2,16 @@
 package analyzer
 
 import (
+	"fmt"
 	"go/ast"
-	"go/token"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/printf"
 	"golang.org/x/tools/go/ast/inspector"
 
 	"github.com/MirrexOne/unqueryvet/pkg/config"
@@ -29,10 +31,11 @@ const (
 // NewAnalyzer creates the Unqueryvet analyzer with enhanced logic for production use
 func NewAnalyzer() *analysis.Analyzer {
 	return &analysis.Analyzer{
-		Name:     "unqueryvet",
-		Doc:      "detects SELECT * in SQL queries and SQL builders, preventing performance issues and encouraging explicit column selection",
-		Run:      run,
-		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Name:      "unqueryvet",
+		Doc:       "detects SELECT * in SQL queries and SQL builders, preventing performance issues and encouraging explicit column selection",
+		Run:       run,
+		Requires:  []*analysis.Analyzer{inspect.Analyzer, buildssa.Analyzer, printf.Analyzer},
+		FactTypes: []analysis.Fact{new(sqlQueryFact), new(builderFact)},
 	}
 }
 
@@ -44,96 +47,128 @@ func NewAnalyzerWithSettings(s config.UnqueryvetSettings) *analysis.Analyzer {
 		Run: func(pass *analysis.Pass) (any, error) {
 			return RunWithConfig(pass, &s)
 		},
-		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Requires:  []*analysis.Analyzer{inspect.Analyzer, buildssa.Analyzer, printf.Analyzer},
+		FactTypes: []analysis.Fact{new(sqlQueryFact), new(builderFact)},
 	}
 }
 
 // RunWithConfig performs analysis with provided configuration
 // This is the main entry point for configured analysis
 func RunWithConfig(pass *analysis.Pass, cfg *config.UnqueryvetSettings) (any, error) {
-	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
-
 	// Use provided configuration or default if nil
 	if cfg == nil {
 		defaultSettings := config.DefaultSettings()
 		cfg = &defaultSettings
 	}
 
-	// Define AST node types we're interested in
-	nodeFilter := []ast.Node{
-		(*ast.CallExpr)(nil),   // Function/method calls
-		(*ast.File)(nil),       // Files (for SQL builder analysis)
-		(*ast.AssignStmt)(nil), // Assignment statements for standalone literals
+	// Load the schema catalog used to suggest explicit column lists
+	cfg, err := withCatalog(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("loading schema: %w", err)
 	}
 
-	// Walk through all AST nodes and analyze them
-	insp.Preorder(nodeFilter, func(n ast.Node) {
-		switch node := n.(type) {
-		case *ast.File:
-			// Analyze SQL builders only if enabled in configuration
-			if cfg.CheckSQLBuilders {
-				analyzeSQLBuilders(pass, node)
-			}
-		case *ast.AssignStmt:
-			// Check assignment statements for standalone SQL literals
-			checkAssignStmt(pass, node, cfg)
-		case *ast.CallExpr:
-			// Analyze function calls for SQL with SELECT * usage
-			checkCallExpr(pass, node, cfg)
-		}
-	})
+	// Skip ignored packages and excluded files before inspecting anything
+	if isIgnoredPackage(pass, cfg) {
+		return nil, nil
+	}
+	files := includedFiles(pass, cfg)
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if len(files) != len(pass.Files) {
+		insp = inspector.New(files)
+	}
 
-	return nil, nil
-}
+	// Collect diagnostics so that a query reached from several places is reported once
+	report := pass.Report
+	pass, collector := collectDiagnostics(pass)
+	defer collector.flush(report)
 
-// run performs the main analysis of Go code files for SELECT * usage
-func run(pass *analysis.Pass) (any, error) {
-	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	// Export queries held in package-level declarations before they are resolved
+	exportSQLFacts(pass, files)
 
 	// Define AST node types we're interested in
 	nodeFilter := []ast.Node{
 		(*ast.CallExpr)(nil),   // Function/method calls
 		(*ast.File)(nil),       // Files (for SQL builder analysis)
 		(*ast.AssignStmt)(nil), // Assignment statements for standalone literals
+		(*ast.ValueSpec)(nil),  // Constant and variable declarations
 	}
 
-	// Always use default settings since passing settings through ResultOf doesn't work reliably
-	defaultSettings := config.DefaultSettings()
-	cfg := &defaultSettings
-
 	// Walk through all AST nodes and analyze them
 	insp.Preorder(nodeFilter, func(n ast.Node) {
 		switch node := n.(type) {
 		case *ast.File:
 			// Analyze SQL builders only if enabled in configuration
 			if cfg.CheckSQLBuilders {
-				analyzeSQLBuilders(pass, node)
+				analyzeSQLBuilders(pass, node, cfg)
+				analyzeGORMChains(pass, node, cfg)
+				analyzeGoquDatasets(pass, node, cfg)
+				analyzeBunQueries(pass, node, cfg)
+				analyzeJetStatements(pass, node, cfg)
 			}
 		case *ast.AssignStmt:
-			// Check assignment statements for standalone SQL literals
-			checkAssignStmt(pass, node, cfg)
+			// Check assignment statements for standalone SQL literals,
+			// in strict mode only through the sinks they reach
+			if !cfg.StrictSinks {
+				checkAssignStmt(pass, node, cfg)
+			}
+		case *ast.ValueSpec:
+			// Check constant and variable declarations for SQL literals
+			if !cfg.StrictSinks {
+				checkValueSpec(pass, node, cfg)
+			}
 		case *ast.CallExpr:
 			// Analyze function calls for SQL with SELECT * usage
 			checkCallExpr(pass, node, cfg)
 		}
 	})
 
+	funcs := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA).SrcFuncs
+
+	// Follow SQL builders without columns through variables, branches and helpers
+	if cfg.CheckSQLBuilders {
+		checkBuilderFlows(pass, funcs, files, cfg)
+	}
+
+	// Follow queries assembled in local variables into the calls they reach
+	checkSQLFlows(pass, funcs, files, cfg)
+
+	// Follow the rows of SELECT * queries into positional Scan calls
+	if cfg.CheckPositionalScan {
+		checkScanFlows(pass, funcs, files, cfg)
+	}
+
 	return nil, nil
 }
 
+// run performs the main analysis of Go code files for SELECT * usage
+func run(pass *analysis.Pass) (any, error) {
+	// Use default settings; drivers with settings use NewAnalyzerWithSettings or RunWithConfig
+	return RunWithConfig(pass, nil)
+}
+
 // checkAssignStmt checks assignment statements for standalone SQL literals
 func checkAssignStmt(pass *analysis.Pass, stmt *ast.AssignStmt, cfg *config.UnqueryvetSettings) {
 	// Check right-hand side expressions for string literals with SELECT *
 	for _, expr := range stmt.Rhs {
-		// Only check direct string literals, not function calls
-		if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
-			content := normalizeSQLQuery(lit.Value)
-			if isSelectStarQuery(content, cfg) {
-				pass.Report(analysis.Diagnostic{
-					Pos:     lit.Pos(),
-					Message: getWarningMessage(),
-				})
-			}
+		checkDefinedSQL(pass, expr, cfg)
+	}
+}
+
+// checkValueSpec checks constant and variable declarations for SQL literals
+func checkValueSpec(pass *analysis.Pass, spec *ast.ValueSpec, cfg *config.UnqueryvetSettings) {
+	for _, expr := range spec.Values {
+		checkDefinedSQL(pass, expr, cfg)
+	}
+}
+
+// checkDefinedSQL checks a constant string expression that defines a query:
+// a literal or a concatenation of literals and constants. Plain references to
+// named constants are skipped, they are reported where the constant is defined.
+func checkDefinedSQL(pass *analysis.Pass, expr ast.Expr, cfg *config.UnqueryvetSettings) {
+	switch ast.Unparen(expr).(type) {
+	case *ast.BasicLit, *ast.BinaryExpr:
+		if src, ok := constantSource(pass, expr); ok {
+			checkSQLSource(pass, src, nil, cfg)
 		}
 	}
 }
@@ -141,25 +176,88 @@ func checkAssignStmt(pass *analysis.Pass, stmt *ast.AssignStmt, cfg *config.Unqu
 // checkCallExpr analyzes function calls for SQL with SELECT * usage
 // Includes checking arguments and SQL builders
 func checkCallExpr(pass *analysis.Pass, call *ast.CallExpr, cfg *config.UnqueryvetSettings) {
-	// Check SQL builders for SELECT * in arguments
-	if cfg.CheckSQLBuilders && isSQLBuilderSelectStar(call) {
-		pass.Report(analysis.Diagnostic{
-			Pos:     call.Pos(),
-			Message: getDetailedWarningMessage("sql_builder"),
-		})
+	// Arguments of ignored functions are never reported
+	if isIgnoredCall(pass, call, cfg) {
+		return
+	}
+
+	// Select("*") of SQL builders is reported by analyzeSQLBuilders
+	if cfg.CheckSQLBuilders && isSQLBuilderSelectStar(pass, call, cfg) {
 		return
 	}
 
-	// Check function call arguments for strings with SELECT *
+	// In strict mode only the arguments of sinks are checked
+	if !isCheckedCall(pass, call, cfg) {
+		return
+	}
+
+	// Check function call arguments for constant strings with SELECT *
 	for _, arg := range call.Args {
-		if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.STRING {
-			content := normalizeSQLQuery(lit.Value)
-			if isSelectStarQuery(content, cfg) {
-				pass.Report(analysis.Diagnostic{
-					Pos:     lit.Pos(),
-					Message: getWarningMessage(),
-				})
-			}
+		if src, ok := constantSource(pass, arg); ok {
+			checkSQLSource(pass, src, call, cfg)
+		}
+	}
+
+	// Check the text printf-like functions format, with constant arguments inserted
+	if variants, ok := formatSource(pass, call); ok {
+		for _, src := range variants {
+			checkSQLSource(pass, src, call, cfg)
+		}
+	}
+}
+
+// checkSQLSource checks a query and reports its findings at their exact positions in the
+// Go source. When a finding lies outside of the use node, e.g. in the definition of a
+// constant passed to a call, the use is attached as related information.
+func checkSQLSource(pass *analysis.Pass, src sqlSource, use ast.Node, cfg *config.UnqueryvetSettings) {
+	diagnostic := func(offset int, message string) analysis.Diagnostic {
+		pos := src.posAt(offset)
+		related := src.related
+		if use != nil && (pos < use.Pos() || pos >= use.End()) {
+			related = append(related[:len(related):len(related)], analysis.RelatedInformation{
+				Pos:     use.Pos(),
+				End:     use.End(),
+				Message: "query used here",
+			})
+		}
+		return analysis.Diagnostic{Pos: pos, Message: message, Related: related}
+	}
+	report := func(offset int, message string, fixes ...analysis.SuggestedFix) {
+		d := diagnostic(offset, message)
+		d.SuggestedFixes = fixes
+		pass.Report(d)
+	}
+
+	// Stars exposing sensitive columns are reported as such instead of the usual warning
+	sensitiveStars := make(map[int]bool)
+	if patterns := sensitivePatterns(cfg); len(patterns) > 0 {
+		for _, selection := range findSensitiveSelections(src.text, patterns, isSensitiveAllowed(pass, cfg), cfg) {
+			d := diagnostic(selection.Offset, selection.Message)
+			d.Category = sensitiveCategory
+			pass.Report(d)
+			sensitiveStars[selection.Offset] = selection.Star
+		}
+	}
+
+	for _, star := range selectStarsInQuery(src.text, cfg) {
+		if sensitiveStars[star.Offset] {
+			continue
+		}
+		d := diagnostic(star.Offset, getStarWarningMessage(star))
+		d.SuggestedFixes = starFixes(pass, src, star, use, cfg)
+		if star.Context == contextReturning || star.Context == contextOutput {
+			d.Category = returningCategory
+		}
+		pass.Report(d)
+	}
+	if cfg.Catalog != nil {
+		for _, column := range findUnknownColumns(src.text, cfg.Catalog) {
+			report(column.Offset, unknownColumnMessage(column))
+		}
+	}
+	if cfg.CheckInsertColumnList {
+		for _, insert := range findPositionalInserts(src.text) {
+			report(insert.Offset, getDetailedWarningMessage("insert_columns"))
 		}
 	}
 }
@@ -171,30 +269,41 @@ func NormalizeSQLQuery(query string) string {
 }
 
 func normalizeSQLQuery(query string) string {
+	return normalizeSQLText(unquoteSQLLiteral(query))
+}
+
+// unquoteSQLLiteral returns the value of a Go string literal with advanced escape sequence handling.
+// Text that is not a quoted literal is returned unchanged.
+func unquoteSQLLiteral(query string) string {
 	if len(query) < 2 {
 		return query
 	}
 
 	first, last := query[0], query[len(query)-1]
 
-	// 1. Handle different quote types with escape sequence processing
+	// Handle different quote types with escape sequence processing
 	if first == '"' && last == '"' {
 		// For regular strings check for escape sequences
 		if !strings.Contains(query, "\\") {
-			query = trimQuotes(query)
-		} else if unquoted, err := strconv.Unquote(query); err == nil {
+			return trimQuotes(query)
+		}
+		if unquoted, err := strconv.Unquote(query); err == nil {
 			// Use standard Go unquoting for proper escape sequence handling
-			query = unquoted
-		} else {
-			// Fallback: simple quote removal
-			query = trimQuotes(query)
+			return unquoted
 		}
-	} else if first == '`' && last == '`' {
+		// Fallback: simple quote removal
+		return trimQuotes(query)
+	}
+	if first == '`' && last == '`' {
 		// Raw strings - simply remove backticks
-		query = trimQuotes(query)
+		return trimQuotes(query)
 	}
+	return query
+}
 
-	// 2. Process comments line by line before normalization
+// normalizeSQLText strips line comments, collapses whitespace and upper-cases SQL text
+func normalizeSQLText(query string) string {
+	// 1. Process comments line by line before normalization
 	lines := strings.Split(query, "\n")
 	var processedParts []string
 
@@ -210,7 +319,7 @@ func normalizeSQLQuery(query string) string {
 		}
 	}
 
-	// 3. Reassemble query and normalize
+	// 2. Reassemble query and normalize
 	query = strings.Join(processedParts, " ")
 	query = strings.ToUpper(query)
 	query = strings.ReplaceAll(query, "\t", " ")
@@ -231,27 +340,51 @@ func IsSelectStarQuery(query string, cfg *config.UnqueryvetSettings) bool {
 }
 
 func isSelectStarQuery(query string, cfg *config.UnqueryvetSettings) bool {
+	return len(selectStarsInQuery(query, cfg)) > 0
+}
+
+// selectStarsInQuery returns the star projections of query that should be reported under cfg
+func selectStarsInQuery(query string, cfg *config.UnqueryvetSettings) []selectStar {
 	// Check allowed patterns first - if query matches an allowed pattern, ignore it
-	for _, pattern := range cfg.AllowedPatterns {
-		if matched, _ := regexp.MatchString(pattern, query); matched {
-			return false
-		}
+	if isAllowedQuery(query, cfg) {
+		return nil
 	}
 
-	// Check for SELECT * in query (case-insensitive)
-	upperQuery := strings.ToUpper(query)
-	if strings.Contains(upperQuery, "SELECT *") { //nolint:unqueryvet
-		// Ensure this is actually an SQL query by checking for SQL keywords
-		sqlKeywords := []string{"FROM", "WHERE", "JOIN", "GROUP", "ORDER", "HAVING", "UNION", "LIMIT"}
-		for _, keyword := range sqlKeywords {
-			if strings.Contains(upperQuery, keyword) {
-				return true
-			}
+	// Tokenize the query and look for a star in any projection list
+	var stars []selectStar
+	for _, star := range findSelectStars(query) {
+		// RETURNING u.* and OUTPUT inserted.* are qualified too, CheckReturning alone controls them
+		returning := star.Context == contextReturning || star.Context == contextOutput
+		if returning && !cfg.CheckReturning {
+			continue
+		}
+		if !returning && star.Qualifier != "" && !cfg.CheckQualifiedStars {
+			continue
+		}
+		if star.Context == contextExists && cfg.AllowStarInExists {
+			continue
+		}
+		if star.Context == contextInsertSelect && !cfg.CheckInsertSelectStar {
+			continue
 		}
+		stars = append(stars, star)
+	}
+	return stars
+}
+
+// isAllowedQuery reports whether query matches one of the allowed patterns.
+// Patterns are tried against the query as written and against its normalized form.
+func isAllowedQuery(query string, cfg *config.UnqueryvetSettings) bool {
+	if len(cfg.AllowedPatterns) == 0 {
+		return false
+	}
 
-		// Also check if it's just "SELECT *" without other keywords (still problematic)
-		trimmed := strings.TrimSpace(upperQuery)
-		if trimmed == "SELECT *" {
+	normalized := normalizeSQLText(query)
+	for _, pattern := range cfg.AllowedPatterns {
+		if matched, _ := regexp.MatchString(pattern, query); matched {
+			return true
+		}
+		if matched, _ := regexp.MatchString(pattern, normalized); matched {
 			return true
 		}
 	}
@@ -263,6 +396,16 @@ func getWarningMessage() string {
 	return defaultWarningMessage
 }
 
+// getStarWarningMessage returns warning message for the context of a star found in a query,
+// naming the qualifier of a qualified star
+func getStarWarningMessage(star selectStar) string {
+	message := getDetailedWarningMessage(star.Context)
+	if star.Qualifier == "" {
+		return message
+	}
+	return strings.Replace(message, "*", star.Qualifier+".*", 1)
+}
+
 // getDetailedWarningMessage returns context-specific warning message
 func getDetailedWarningMessage(context string) string {
 	switch context {
@@ -270,157 +413,31 @@ func getDetailedWarningMessage(context string) string {
 		return "avoid SELECT * in SQL builder - explicitly specify columns to prevent unnecessary data transfer and schema change issues"
 	case "nested":
 		return "avoid SELECT * in subquery - can cause performance issues and unexpected results when schema changes"
+	case "cte":
+		return "avoid SELECT * in CTE - every column is carried through the query and the CTE shape changes with the schema"
+	case "derived_table":
+		return "avoid SELECT * in derived table - explicitly specify columns to keep the outer query stable when schema changes"
+	case "exists":
+		return "avoid SELECT * in EXISTS subquery - use SELECT 1 to make clear that no columns are needed"
+	case "returning":
+		return "avoid RETURNING * - explicitly specify returned columns to keep results stable when schema changes"
+	case "output":
+		return "avoid OUTPUT * - explicitly specify output columns to keep results stable when schema changes"
+	case "insert_select":
+		return "avoid INSERT ... SELECT * - columns are copied by position and break silently when either table changes"
+	case "insert_columns":
+		return "avoid INSERT without column list - values are matched to columns by position and break silently when the table changes"
+	case "union":
+		return "avoid SELECT * in UNION branch - column count and order must match across branches and break when schema changes"
+	case "gorm_finder":
+		return "GORM query without Select() loads every column with SELECT * - add .Select() with the needed columns"
+	case "goqu_select":
+		return "goqu dataset without Select() renders SELECT * - add .Select() with the needed columns"
+	case "bun_select":
+		return "bun select query without Column() or Model() selects every column with SELECT * - add .Column() or .Model()"
 	case "empty_select":
 		return "SQL builder Select() without columns defaults to SELECT * - add specific columns with .Columns() method"
 	default:
 		return defaultWarningMessage
 	}
 }
-
-// isSQLBuilderSelectStar checks SQL builder method calls for SELECT * usage
-func isSQLBuilderSelectStar(call *ast.CallExpr) bool {
-	fun, ok := call.Fun.(*ast.SelectorExpr)
-	if !ok {
-		return false
-	}
-
-	// Check that this is a Select method call
-	if fun.Sel == nil || fun.Sel.Name != selectKeyword {
-		return false
-	}
-
-	if len(call.Args) == 0 {
-		return false
-	}
-
-	// Check Select method arguments for "*" or empty strings
-	for _, arg := range call.Args {
-		if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.STRING {
-			value := strings.Trim(lit.Value, "`\"")
-			// Consider both "*" and empty strings in Select() as problematic
-			if value == "*" || value == "" {
-				return true
-			}
-		}
-	}
-
-	return false
-}
-
-// analyzeSQLBuilders performs advanced SQL builder analysis
-// Key logic for handling edge-cases like Select().Columns("*")
-func analyzeSQLBuilders(pass *analysis.Pass, file *ast.File) {
-	// Track SQL builder variables and their state
-	builderVars := make(map[string]*ast.CallExpr) // Variables with empty Select() calls
-	hasColumns := make(map[string]bool)           // Flag: were columns added for variable
-
-	// First pass: find variables created with empty Select() calls
-	ast.Inspect(file, func(n ast.Node) bool {
-		switch node := n.(type) {
-		case *ast.AssignStmt:
-			// Analyze assignments like: query := builder.Select()
-			for i, expr := range node.Rhs {
-				if call, ok := expr.(*ast.CallExpr); ok {
-					if isEmptySelectCall(call) {
-						// Found empty Select() call, remember the variable
-						if i < len(node.Lhs) {
-							if ident, ok := node.Lhs[i].(*ast.Ident); ok {
-								builderVars[ident.Name] = call
-								hasColumns[ident.Name] = false
-							}
-						}
-					}
-				}
-			}
-		}
-		return true
-	})
-
-	// Second pass: check usage of Columns/Column methods
-	ast.Inspect(file, func(n ast.Node) bool {
-		switch node := n.(type) {
-		case *ast.CallExpr:
-			if sel, ok := node.Fun.(*ast.SelectorExpr); ok {
-				// Check calls to Columns() or Column() methods
-				if sel.Sel != nil && (sel.Sel.Name == columnsKeyword || sel.Sel.Name == columnKeyword) {
-					// Check for "*" in arguments
-					if hasStarInColumns(node) {
-						pass.Report(analysis.Diagnostic{
-							Pos:     node.Pos(),
-							Message: getDetailedWarningMessage("sql_builder"),
-						})
-					}
-
-					// Update variable state - columns were added
-					if ident, ok := sel.X.(*ast.Ident); ok {
-						if _, exists := builderVars[ident.Name]; exists {
-							if !hasStarInColumns(node) {
-								hasColumns[ident.Name] = true
-							}
-						}
-					}
-				}
-			}
-
-			// Check call chains like builder.Select().Columns("*")
-			if isSelectWithColumns(node) {
-				if hasStarInColumns(node) {
-					if sel, ok := node.Fun.(*ast.SelectorExpr); ok && sel.Sel != nil {
-						pass.Report(analysis.Diagnostic{
-							Pos:     node.Pos(),
-							Message: getDetailedWarningMessage("sql_builder"),
-						})
-					}
-				}
-				return true
-			}
-		}
-		return true
-	})
-
-	// Final check: warn about builders with empty Select() without subsequent columns
-	for varName, call := range builderVars {
-		if !hasColumns[varName] {
-			pass.Report(analysis.Diagnostic{
-				Pos:     call.Pos(),
-				Message: getDetailedWarningMessage("empty_select"),
-			})
-		}
-	}
-}
-
-// isEmptySelectCall checks if call is an empty Select()
-func isEmptySelectCall(call *ast.CallExpr) bool {
-	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-		if sel.Sel != nil && sel.Sel.Name == selectKeyword && len(call.Args) == 0 {
-			return true
-		}
-	}
-	return false
-}
-
-// isSelectWithColumns checks call chains like Select().Columns()
-func isSelectWithColumns(call *ast.CallExpr) bool {
-	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-		if sel.Sel != nil && (sel.Sel.Name == columnsKeyword || sel.Sel.Name == columnKeyword) {
-			// Check that previous call in chain is Select()
-			if innerCall, ok := sel.X.(*ast.CallExpr); ok {
-				return isEmptySelectCall(innerCall)
-			}
-		}
-	}
-	return false
-}
-
-// hasStarInColumns checks if call arguments contain "*" symbol
-func hasStarInColumns(call *ast.CallExpr) bool {
-	for _, arg := range call.Args {
-		if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.STRING {
-			value := strings.Trim(lit.Value, "`\"")
-			if value == "*" {
-				return true
-			}
-		}
-	}
-	return false
-}
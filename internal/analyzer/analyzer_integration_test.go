@@ -1,11 +1,15 @@
 package analyzer_test
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"golang.org/x/tools/go/analysis/analysistest"
 
 	"github.com/MirrexOne/unqueryvet/internal/analyzer"
+	"github.com/MirrexOne/unqueryvet/pkg/config"
 )
 
 func TestAnalyzer(t *testing.T) {
@@ -22,3 +26,194 @@ func TestAnalyzerWithSettings(t *testing.T) {
 	// Test with SQL builders detection
 	analysistest.Run(t, testdata, analyzer.NewAnalyzer(), "integration")
 }
+
+func TestAnalyzerStarInExistsDisallowed(t *testing.T) {
+	settings := config.DefaultSettings()
+	settings.AllowStarInExists = false
+
+	analysistest.Run(t, analysistest.TestData(), analyzer.NewAnalyzerWithSettings(settings), "exists")
+}
+
+func TestAnalyzerInsertChecks(t *testing.T) {
+	settings := config.DefaultSettings()
+	settings.CheckInsertColumnList = true
+	settings.CheckInsertSelectStar = true
+
+	analysistest.Run(t, analysistest.TestData(), analyzer.NewAnalyzerWithSettings(settings), "inserts")
+}
+
+func TestAnalyzerIgnoredFunctions(t *testing.T) {
+	settings := config.DefaultSettings()
+	settings.IgnoredFunctions = []string{
+		"fmt.Printf",
+		"log.*",
+		"(*database/sql.DB).Query",
+		"ignored.Logger.Debug",
+	}
+
+	analysistest.Run(t, analysistest.TestData(), analyzer.NewAnalyzerWithSettings(settings), "ignored")
+}
+
+func TestAnalyzerIgnoredPackages(t *testing.T) {
+	settings := config.DefaultSettings()
+	settings.IgnoredPackages = []string{"skipped/..."}
+
+	analysistest.Run(t, analysistest.TestData(), analyzer.NewAnalyzerWithSettings(settings), "skipped", "skipped/generated")
+}
+
+func TestAnalyzerExcludeFiles(t *testing.T) {
+	settings := config.DefaultSettings()
+	settings.ExcludeFiles = []string{"*_gen.go"}
+
+	analysistest.Run(t, analysistest.TestData(), analyzer.NewAnalyzerWithSettings(settings), "excluded")
+}
+
+func TestAnalyzerStrictSinks(t *testing.T) {
+	settings := config.DefaultSettings()
+	settings.StrictSinks = true
+	settings.Sinks = []string{"sinks.Store.Run"}
+
+	analysistest.Run(t, analysistest.TestData(), analyzer.NewAnalyzerWithSettings(settings), "sinks")
+}
+
+func TestAnalyzerSQLBuilders(t *testing.T) {
+	settings := config.DefaultSettings()
+	settings.SQLBuilders = []string{"example.com/qb"}
+
+	analysistest.Run(t, analysistest.TestData(), analyzer.NewAnalyzerWithSettings(settings), "builders")
+}
+
+func TestAnalyzerBuilderFlows(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), analyzer.NewAnalyzer(), "builderhelpers", "builderflows")
+}
+
+func TestAnalyzerGORM(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), analyzer.NewAnalyzer(), "gormchains")
+}
+
+func TestAnalyzerGoqu(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), analyzer.NewAnalyzer(), "goqudatasets")
+}
+
+func TestAnalyzerBun(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), analyzer.NewAnalyzer(), "bunqueries")
+}
+
+func TestAnalyzerJet(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), analyzer.NewAnalyzer(), "jetstatements")
+}
+
+func TestAnalyzerFlow(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), analyzer.NewAnalyzer(), "flow")
+}
+
+func TestAnalyzerFormat(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), analyzer.NewAnalyzer(), "format")
+}
+
+func TestDiagnosticPositions(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, analyzer.NewAnalyzer(), "positions", "flow", "format")
+
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			position := result.Pass.Fset.Position(diag.Pos)
+			content, err := os.ReadFile(position.Filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if content[position.Offset] != '*' {
+				t.Errorf("%s: diagnostic %q does not point at the star", position, diag.Message)
+			}
+		}
+	}
+}
+
+func TestConstantRelatedInformation(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, analyzer.NewAnalyzer(), "constants")
+
+	related := make(map[int]int)
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			line := result.Pass.Fset.Position(diag.Pos).Line
+			related[line] = len(diag.Related)
+		}
+	}
+
+	// allOrders is defined on line 10 and used by two calls, directly and through aliasedOrders
+	if related[10] != 2 {
+		t.Errorf("definition of allOrders has %d related use sites, want 2", related[10])
+	}
+	// queries.AllUsers is used on line 45 and points back to its definition
+	if related[45] != 1 {
+		t.Errorf("use of queries.AllUsers has %d related locations, want 1", related[45])
+	}
+	// queries.ActiveUsers is a variable used on line 54 and points back to its definition through its fact
+	if related[54] != 1 {
+		t.Errorf("use of queries.ActiveUsers has %d related locations, want 1", related[54])
+	}
+}
+
+func TestReturningCategory(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), analyzer.NewAnalyzer(), "a")
+
+	returning := 0
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			position := result.Pass.Fset.Position(diag.Pos)
+			switch {
+			case strings.HasPrefix(diag.Message, "avoid RETURNING"), strings.HasPrefix(diag.Message, "avoid OUTPUT"):
+				returning++
+				if diag.Category != "returning" {
+					t.Errorf("%s: diagnostic %q has category %q, want returning", position, diag.Message, diag.Category)
+				}
+			case diag.Category == "returning":
+				t.Errorf("%s: diagnostic %q has category returning", position, diag.Message)
+			}
+		}
+	}
+	if returning == 0 {
+		t.Error("no RETURNING or OUTPUT star was reported")
+	}
+}
+
+func TestSuggestedFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+	settings := config.DefaultSettings()
+	settings.Schema = filepath.Join(testdata, "schema", "schema.sql")
+
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer.NewAnalyzerWithSettings(settings), "fixes")
+}
+
+func TestUnknownColumns(t *testing.T) {
+	testdata := analysistest.TestData()
+	settings := config.DefaultSettings()
+	settings.Schema = filepath.Join(testdata, "schema", "schema.sql")
+
+	analysistest.Run(t, testdata, analyzer.NewAnalyzerWithSettings(settings), "columns")
+}
+
+func TestPositionalScans(t *testing.T) {
+	testdata := analysistest.TestData()
+	settings := config.DefaultSettings()
+	settings.Schema = filepath.Join(testdata, "schema", "schema.sql")
+
+	analysistest.Run(t, testdata, analyzer.NewAnalyzerWithSettings(settings), "scans")
+}
+
+func TestSensitiveColumns(t *testing.T) {
+	testdata := analysistest.TestData()
+	settings := config.DefaultSettings()
+	settings.Schema = filepath.Join(testdata, "schema", "sensitive.json")
+	settings.Sensitive = config.SensitiveSettings{
+		Columns:         []string{"users.password_hash", "*.ssn", "audit_log"},
+		AllowedPackages: []string{"sensitive/auth"},
+	}
+
+	analysistest.Run(t, testdata, analyzer.NewAnalyzerWithSettings(settings), "sensitive/...")
+}
+
+func TestScanDestinationFixes(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analyzer.NewAnalyzer(), "scandest")
+}
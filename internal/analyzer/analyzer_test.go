@@ -1,8 +1,13 @@
 package analyzer
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/MirrexOne/unqueryvet/internal/sqllex"
 	"github.com/MirrexOne/unqueryvet/pkg/config"
 )
 
@@ -124,6 +129,71 @@ func TestIsSelectStarQuery(t *testing.T) {
 			input:    "SELECT * FROM users WHERE active = 1 ORDER BY created_at DESC",
 			expected: true,
 		},
+		{
+			name:     "SELECT DISTINCT * with extra spaces",
+			input:    "SELECT  DISTINCT * FROM users",
+			expected: true,
+		},
+		{
+			name:     "block comment between SELECT and star",
+			input:    "SELECT/**/* FROM users",
+			expected: true,
+		},
+		{
+			name:     "lowercase select with newline before star",
+			input:    "select\n*\nfrom users",
+			expected: true,
+		},
+		{
+			name:     "SELECT TOP with star",
+			input:    "SELECT TOP 10 * FROM users",
+			expected: true,
+		},
+		{
+			name:     "SELECT * without FROM clause",
+			input:    "SELECT * FROM",
+			expected: true,
+		},
+		{
+			name:     "SELECT * inside string literal value",
+			input:    "INSERT INTO audit (query) VALUES ('SELECT * FROM x')",
+			expected: false,
+		},
+		{
+			name:     "SELECT * inside line comment",
+			input:    "SELECT id FROM users -- was SELECT * FROM users",
+			expected: false,
+		},
+		{
+			name:     "SELECT * inside block comment",
+			input:    "SELECT id /* SELECT * FROM users */ FROM users",
+			expected: false,
+		},
+		{
+			name:     "star in arithmetic",
+			input:    "SELECT price * quantity FROM orders",
+			expected: false,
+		},
+		{
+			name:     "star in function call without allowed patterns",
+			input:    "SELECT COUNT(*) FROM users",
+			expected: false,
+		},
+		{
+			name:     "star among other columns",
+			input:    "SELECT id, * FROM users",
+			expected: true,
+		},
+		{
+			name:     "prose that mentions select",
+			input:    "please select * items you need",
+			expected: false,
+		},
+		{
+			name:     "quoted identifier named SELECT",
+			input:    `SELECT "select" FROM users`,
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -136,6 +206,486 @@ func TestIsSelectStarQuery(t *testing.T) {
 	}
 }
 
+func TestQualifiedSelectStars(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		qualifiers []string
+	}{
+		{
+			name:       "table alias star",
+			input:      "SELECT u.*, o.total FROM users u JOIN orders o ON o.user_id = u.id",
+			qualifiers: []string{"u"},
+		},
+		{
+			name:       "quoted schema and table",
+			input:      `SELECT "schema"."table".* FROM "schema"."table"`,
+			qualifiers: []string{`"schema"."table"`},
+		},
+		{
+			name:       "bare and qualified stars",
+			input:      "SELECT *, u.* FROM users u",
+			qualifiers: []string{"", "u"},
+		},
+		{
+			name:       "star after column reference is multiplication",
+			input:      "SELECT u.price * 2 FROM users u",
+			qualifiers: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stars := findSelectStars(tt.input)
+			if len(stars) != len(tt.qualifiers) {
+				t.Fatalf("findSelectStars(%q) = %+v, want qualifiers %q", tt.input, stars, tt.qualifiers)
+			}
+			for i, star := range stars {
+				if star.Qualifier != tt.qualifiers[i] {
+					t.Errorf("star %d qualifier = %q, want %q", i, star.Qualifier, tt.qualifiers[i])
+				}
+				if tt.input[star.Offset] != '*' {
+					t.Errorf("star %d offset %d does not point at '*'", i, star.Offset)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectStarContexts(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		contexts []string
+	}{
+		{"top level", "SELECT * FROM users", []string{contextTopLevel}},
+		{"IN subquery", "SELECT id FROM t WHERE id IN (SELECT * FROM u)", []string{contextSubquery}},
+		{"scalar subquery in projection", "SELECT id, (SELECT * FROM u LIMIT 1) FROM t", []string{contextSubquery}},
+		{"redundant parentheses", "SELECT id FROM t WHERE id = ((SELECT * FROM u))", []string{contextSubquery}},
+		{"CTE", "WITH a AS (SELECT * FROM t), b AS MATERIALIZED (SELECT * FROM u) SELECT id FROM a", []string{contextCTE, contextCTE}},
+		{"derived table", "SELECT x.id FROM (SELECT * FROM t) x", []string{contextDerivedTable}},
+		{"derived table after comma", "SELECT x.id FROM a, (SELECT * FROM t) x", []string{contextDerivedTable}},
+		{"lateral join", "SELECT x.id FROM a CROSS JOIN LATERAL (SELECT * FROM t) x", []string{contextDerivedTable}},
+		{"union branches", "SELECT * FROM a UNION SELECT * FROM b EXCEPT (SELECT * FROM c)", []string{contextTopLevel, contextUnion, contextUnion}},
+		{"create table as", "CREATE TABLE t2 AS (SELECT * FROM t)", []string{contextTopLevel}},
+		{"EXISTS", "SELECT id FROM t WHERE EXISTS (SELECT * FROM u WHERE u.t_id = t.id)", []string{contextExists}},
+		{"NOT EXISTS", "SELECT id FROM t WHERE NOT EXISTS (SELECT * FROM u)", []string{contextExists}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stars := findSelectStars(tt.input)
+			if len(stars) != len(tt.contexts) {
+				t.Fatalf("findSelectStars(%q) = %+v, want contexts %q", tt.input, stars, tt.contexts)
+			}
+			for i, star := range stars {
+				if star.Context != tt.contexts[i] {
+					t.Errorf("star %d context = %q, want %q", i, star.Context, tt.contexts[i])
+				}
+			}
+		})
+	}
+}
+
+func TestQualifiedStarsSetting(t *testing.T) {
+	query := "SELECT u.* FROM users u"
+
+	if isSelectStarQuery(query, &config.UnqueryvetSettings{CheckQualifiedStars: false}) {
+		t.Error("qualified stars should be ignored when CheckQualifiedStars is disabled")
+	}
+	if !isSelectStarQuery(query, &config.UnqueryvetSettings{CheckQualifiedStars: true}) {
+		t.Error("qualified stars should be reported when CheckQualifiedStars is enabled")
+	}
+	// RETURNING and OUTPUT stars are qualified by nature and follow CheckReturning only
+	returning := []string{
+		"INSERT INTO users (name) OUTPUT inserted.* VALUES (@p1)",
+		"DELETE FROM users OUTPUT deleted.* WHERE id = @p1",
+		"DELETE FROM users u WHERE id = $1 RETURNING u.*",
+	}
+	for _, query := range returning {
+		if !isSelectStarQuery(query, &config.UnqueryvetSettings{CheckQualifiedStars: false, CheckReturning: true}) {
+			t.Errorf("%q should be reported with CheckReturning enabled and CheckQualifiedStars disabled", query)
+		}
+		if isSelectStarQuery(query, &config.UnqueryvetSettings{CheckQualifiedStars: true, CheckReturning: false}) {
+			t.Errorf("%q should be ignored with CheckReturning disabled", query)
+		}
+	}
+}
+
+func TestReturningStars(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		context   string
+		qualifier string
+	}{
+		{"INSERT RETURNING", "INSERT INTO users (name) VALUES ($1) RETURNING *", contextReturning, ""},
+		{"UPDATE RETURNING", "UPDATE users SET name = $1 WHERE id = $2 RETURNING *", contextReturning, ""},
+		{"DELETE RETURNING qualified", "DELETE FROM users u WHERE id = $1 RETURNING u.*", contextReturning, "u"},
+		{"RETURNING in CTE", "WITH d AS (DELETE FROM t RETURNING *) SELECT id FROM d", contextReturning, ""},
+		{"OUTPUT inserted", "INSERT INTO users (name) OUTPUT inserted.* VALUES (@p1)", contextOutput, "inserted"},
+		{"OUTPUT deleted", "DELETE FROM users OUTPUT deleted.* WHERE id = @p1", contextOutput, "deleted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stars := findSelectStars(tt.query)
+			if len(stars) != 1 {
+				t.Fatalf("findSelectStars(%q) = %+v, want one star", tt.query, stars)
+			}
+			if stars[0].Context != tt.context || stars[0].Qualifier != tt.qualifier {
+				t.Errorf("star = %+v, want context %q and qualifier %q", stars[0], tt.context, tt.qualifier)
+			}
+		})
+	}
+
+	explicit := []string{
+		"INSERT INTO users (name) VALUES ($1) RETURNING id, created_at",
+		"SELECT returning FROM t",
+		"SELECT output, * FROM t",
+	}
+	for _, query := range explicit {
+		for _, star := range findSelectStars(query) {
+			if star.Context == contextReturning || star.Context == contextOutput {
+				t.Errorf("findSelectStars(%q) reported %+v", query, star)
+			}
+		}
+	}
+
+	query := "INSERT INTO users (name) VALUES ($1) RETURNING *"
+	if isSelectStarQuery(query, &config.UnqueryvetSettings{CheckReturning: false}) {
+		t.Error("RETURNING * should be ignored when CheckReturning is disabled")
+	}
+}
+
+func TestStarTables(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		tables []tableRef
+		merged bool
+	}{
+		{"single table", "SELECT * FROM users WHERE id = 1", []tableRef{{Name: "users"}}, false},
+		{"aliases", "SELECT * FROM public.users AS u, \"orders\" o", []tableRef{{Name: "public.users", Alias: "u"}, {Name: `"orders"`, Alias: "o"}}, false},
+		{"joins", "SELECT * FROM users u LEFT JOIN orders o ON (o.user_id = u.id) ORDER BY u.id", []tableRef{{Name: "users", Alias: "u"}, {Name: "orders", Alias: "o"}}, false},
+		{"using", "SELECT * FROM users JOIN orders USING (id)", []tableRef{{Name: "users"}, {Name: "orders"}}, true},
+		{"derived table", "SELECT * FROM (SELECT id FROM users) t", []tableRef{{Alias: "t"}}, false},
+		{"table function", "SELECT * FROM generate_series(1, 3) g", []tableRef{{Alias: "g"}}, false},
+		{"common table expression", "WITH users AS (SELECT 1) SELECT * FROM users", []tableRef{{}}, false},
+		{"subquery", "SELECT id FROM a WHERE id IN (SELECT * FROM b)", []tableRef{{Name: "b"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stars := findSelectStars(tt.query)
+			if len(stars) != 1 || stars[0].From == nil {
+				t.Fatalf("findSelectStars(%q) = %+v, want one star with a FROM clause", tt.query, stars)
+			}
+			from := stars[0].From
+			if !reflect.DeepEqual(from.Tables, tt.tables) || from.Merged != tt.merged {
+				t.Errorf("FROM clause = %+v, want tables %+v merged %v", *from, tt.tables, tt.merged)
+			}
+		})
+	}
+
+	if stars := findSelectStars("SELECT * FROM users JOIN \x1a"); len(stars) != 1 || stars[0].From != nil {
+		t.Errorf("a FROM clause with unknown text should not be resolved, got %+v", stars)
+	}
+}
+
+func TestProjectionColumn(t *testing.T) {
+	tests := []struct {
+		item string
+		want columnRef
+		ok   bool
+	}{
+		{"email", columnRef{Name: "email"}, true},
+		{"u.email AS mail", columnRef{Offset: 2, Qualifier: "u", Name: "email"}, true},
+		{`public."users"."email" mail`, columnRef{Offset: 15, Qualifier: `public."users"`, Name: "email"}, true},
+		{"NULL", columnRef{}, false},
+		{"lower(email)", columnRef{}, false},
+		{"id + 1", columnRef{}, false},
+		{"u.*", columnRef{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.item, func(t *testing.T) {
+			got, ok := projectionColumn(sqllex.Tokenize(tt.item))
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("projectionColumn(%q) = %+v, %v, want %+v, %v", tt.item, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestClosestName(t *testing.T) {
+	candidates := []string{"id", "name", "email", "created_at"}
+	tests := map[string]string{
+		"emial":     "email",
+		"NAME_":     "name",
+		"createdat": "created_at",
+		"ids":       "id",
+		"status":    "",
+		"x":         "",
+	}
+
+	for name, want := range tests {
+		if got := closestName(name, candidates); got != want {
+			t.Errorf("closestName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestSensitiveSelections(t *testing.T) {
+	cfg := &config.UnqueryvetSettings{Sensitive: config.SensitiveSettings{
+		Columns: []string{"users.password_hash", "*.ssn", "Audit_*"},
+	}}
+	patterns := sensitivePatterns(cfg)
+
+	tests := []struct {
+		name    string
+		query   string
+		allowed bool
+		want    []string
+	}{
+		{"table named without wildcards", "SELECT * FROM public.users", false, []string{"sensitive data: SELECT * exposes column public.users.password_hash - explicitly specify the needed columns"}},
+		{"whole table", "SELECT a.* FROM audit_events a", false, []string{"sensitive data: SELECT a.* exposes column audit_events.* - explicitly specify the needed columns"}},
+		{"wildcard table needs a catalog", "SELECT * FROM people", false, nil},
+		{"wildcard table column", "SELECT id, SSN FROM people", false, []string{"sensitive data: column people.SSN is selected outside the allowed packages"}},
+		{"allowed package", "SELECT password_hash FROM users", true, nil},
+		{"star in allowed package", "SELECT * FROM users", true, []string{"sensitive data: SELECT * exposes column users.password_hash - explicitly specify the needed columns"}},
+		{"other columns", "SELECT id, name FROM users", false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, selection := range findSensitiveSelections(tt.query, patterns, tt.allowed, cfg) {
+				got = append(got, selection.Message)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findSensitiveSelections(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPositionalInserts(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		tables []string
+	}{
+		{"VALUES without columns", "INSERT INTO users VALUES (1, 'John')", []string{"users"}},
+		{"SELECT without columns", "INSERT INTO users_copy SELECT * FROM users", []string{"users_copy"}},
+		{"qualified target", `INSERT INTO "public"."users" VALUES ($1)`, []string{`"public"."users"`}},
+		{"MySQL modifiers", "INSERT IGNORE INTO users VALUES (1)", []string{"users"}},
+		{"SQLite conflict clause", "INSERT OR REPLACE INTO users VALUES (1)", []string{"users"}},
+		{"DEFAULT VALUES", "INSERT INTO counters DEFAULT VALUES", []string{"counters"}},
+		{"parenthesized query", "INSERT INTO users (SELECT id FROM old_users)", []string{"users"}},
+		{"OUTPUT clause", "INSERT INTO users OUTPUT inserted.id VALUES (@p1)", []string{"users"}},
+		{"explicit columns", "INSERT INTO users (id, name) VALUES (1, 'John')", nil},
+		{"explicit columns with SELECT", "INSERT INTO users (id) SELECT id FROM old_users", nil},
+		{"MySQL SET form", "INSERT INTO users SET id = 1, name = 'John'", nil},
+		{"insert inside string", "SELECT 'INSERT INTO users VALUES (1)' FROM dual", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inserts := findPositionalInserts(tt.query)
+			if len(inserts) != len(tt.tables) {
+				t.Fatalf("findPositionalInserts(%q) = %+v, want tables %q", tt.query, inserts, tt.tables)
+			}
+			for i, insert := range inserts {
+				if insert.Table != tt.tables[i] {
+					t.Errorf("insert %d table = %q, want %q", i, insert.Table, tt.tables[i])
+				}
+				if got := tt.query[insert.Offset : insert.Offset+len(insert.Table)]; got != insert.Table {
+					t.Errorf("insert %d offset points at %q, want %q", i, got, insert.Table)
+				}
+			}
+		})
+	}
+}
+
+func TestInsertSelectStarSetting(t *testing.T) {
+	query := "INSERT INTO users_copy SELECT * FROM users"
+
+	stars := selectStarsInQuery(query, &config.UnqueryvetSettings{CheckInsertSelectStar: true})
+	if len(stars) != 1 || stars[0].Context != contextInsertSelect {
+		t.Errorf("selectStarsInQuery(%q) = %+v, want one INSERT ... SELECT * star", query, stars)
+	}
+
+	stars = selectStarsInQuery(query, &config.UnqueryvetSettings{CheckInsertSelectStar: false})
+	if len(stars) != 0 {
+		t.Errorf("selectStarsInQuery(%q) = %+v, want no stars with CheckInsertSelectStar disabled", query, stars)
+	}
+}
+
+func TestStarInExistsSetting(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		allow    bool
+		expected bool
+	}{
+		{"EXISTS allowed", "SELECT id FROM t WHERE EXISTS (SELECT * FROM u WHERE u.t_id = t.id)", true, false},
+		{"NOT EXISTS allowed", "SELECT id FROM t WHERE NOT EXISTS (SELECT * FROM u)", true, false},
+		{"EXISTS disallowed", "SELECT id FROM t WHERE EXISTS (SELECT * FROM u)", false, true},
+		{"IN subquery is not EXISTS", "SELECT id FROM t WHERE id IN (SELECT * FROM u)", true, true},
+		{"outer star still reported", "SELECT * FROM t WHERE EXISTS (SELECT * FROM u)", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.UnqueryvetSettings{AllowStarInExists: tt.allow}
+			if result := isSelectStarQuery(tt.query, cfg); result != tt.expected {
+				t.Errorf("isSelectStarQuery(%q) = %v, want %v", tt.query, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsSQLStatement(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected bool
+	}{
+		{"SELECT * FROM users", true},
+		{"select id from users where active", true},
+		{"-- users\nSELECT id FROM users", true},
+		{"(SELECT id FROM users) UNION (SELECT id FROM admins)", true},
+		{"INSERT INTO users (id) VALUES ($1)", true},
+		{"UPDATE users SET name = $1", true},
+		{"DELETE FROM users WHERE id = $1", true},
+		{"MERGE INTO users USING staged ON users.id = staged.id", true},
+		{"WITH recent AS (SELECT id FROM orders) SELECT * FROM recent", true},
+		{"DELETE", false},
+		{"Update available", false},
+		{"SELECT 1", false},
+		{"Please select a file from the list", false},
+		{"id, name FROM users", false},
+		{"*", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if result := isSQLStatement(tt.text); result != tt.expected {
+				t.Errorf("isSQLStatement(%q) = %v, want %v", tt.text, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.sql")
+	if _, err := loadCatalog(path); err == nil {
+		t.Fatal("loadCatalog of a missing schema succeeded")
+	}
+
+	write := func(ddl string, modified time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(ddl), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+	modified := time.Now()
+	write("CREATE TABLE users (id int);", modified)
+	catalog, err := loadCatalog(path)
+	if err != nil {
+		t.Fatalf("loadCatalog after the schema was created: %v", err)
+	}
+	if _, ok := catalog.Table("users"); !ok {
+		t.Error("catalog is missing table users")
+	}
+	if again, _ := loadCatalog(path); again != catalog {
+		t.Error("unchanged schema was loaded again")
+	}
+
+	write("CREATE TABLE orders (id int);", modified.Add(time.Second))
+	catalog, err = loadCatalog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := catalog.Table("orders"); !ok {
+		t.Error("catalog was not reloaded after the schema changed")
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{"fmt.Printf", "fmt.Printf", true},
+		{"fmt.Printf", "fmt.Sprintf", false},
+		{"fmt.*", "fmt.Sprintf", true},
+		{"*.Printf", "github.com/sirupsen/logrus.Printf", true},
+		{"(*database/sql.DB).Query", "(*database/sql.DB).Query", true},
+		{"(*database/sql.DB).Query", "(*database/sql.DB).QueryRow", false},
+		{"(*database/sql.DB).Query*", "(*database/sql.DB).QueryRowContext", true},
+		{"github.com/rs/zerolog.Event.Ms?", "github.com/rs/zerolog.Event.Msg", true},
+		{"log.Print?", "log.Printf", true},
+		{"log.Print?", "log.Print", false},
+	}
+
+	for _, tt := range tests {
+		if result := matchGlob(tt.pattern, tt.name); result != tt.expected {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, result, tt.expected)
+		}
+	}
+}
+
+func TestIsFileInDirectory(t *testing.T) {
+	tests := []struct {
+		path     string
+		dir      string
+		expected bool
+	}{
+		{"/project/vendor/github.com/pkg/module.go", "vendor", true},
+		{"/project/src/main.go", "vendor", false},
+		{"/project/myvendor/main.go", "vendor", false},
+		{"/project/vendor.go", "vendor", false},
+		{"/project/db/migrations/001_init.go", "db/migrations", true},
+		{"/project/migrations/001_init.go", "db/migrations", false},
+		{"testdata/input.go", "testdata", true},
+	}
+
+	for _, tt := range tests {
+		if result := isFileInDirectory(tt.path, tt.dir); result != tt.expected {
+			t.Errorf("isFileInDirectory(%q, %q) = %v, want %v", tt.path, tt.dir, result, tt.expected)
+		}
+	}
+}
+
+func TestIsExcludedFile(t *testing.T) {
+	patterns := []string{"*_test.go", "*.pb.go", "db/migrations/*.go", "fixtures/"}
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"/project/service/users_test.go", true},
+		{"/project/api/users.pb.go", true},
+		{"/project/db/migrations/001_init.go", true},
+		{"/project/internal/fixtures/users/data.go", true},
+		{"/project/service/users.go", false},
+		{"/project/migrations/001_init.go", false},
+		{"/project/service/fixtures.go", false},
+	}
+
+	for _, tt := range tests {
+		if result := isExcludedFile(tt.path, patterns); result != tt.expected {
+			t.Errorf("isExcludedFile(%q) = %v, want %v", tt.path, result, tt.expected)
+		}
+	}
+}
+
 func TestConfigLoading(t *testing.T) {
 	// Use default settings to test that they contain expected values
 	defaults := config.DefaultSettings()
@@ -146,6 +696,36 @@ func TestConfigLoading(t *testing.T) {
 		t.Error("CheckSQLBuilders should be enabled by default")
 	}
 
+	// Test that qualified stars are reported by default
+	if !cfg.CheckQualifiedStars {
+		t.Error("CheckQualifiedStars should be enabled by default")
+	}
+
+	// Test that stars in EXISTS subqueries are allowed by default
+	if !cfg.AllowStarInExists {
+		t.Error("AllowStarInExists should be enabled by default")
+	}
+
+	// Test that RETURNING * is reported by default
+	if !cfg.CheckReturning {
+		t.Error("CheckReturning should be enabled by default")
+	}
+
+	// Test that INSERT ... SELECT * is reported by default
+	if !cfg.CheckInsertSelectStar {
+		t.Error("CheckInsertSelectStar should be enabled by default")
+	}
+
+	// Test that SELECT * rows scanned by position are reported by default
+	if !cfg.CheckPositionalScan {
+		t.Error("CheckPositionalScan should be enabled by default")
+	}
+
+	// Test that no functions are ignored by default
+	if len(cfg.IgnoredFunctions) != 0 {
+		t.Error("IgnoredFunctions should be empty by default")
+	}
+
 	// Test that default allowed patterns include COUNT(*) and system tables
 	if len(cfg.AllowedPatterns) == 0 {
 		t.Error("Should have some default allowed patterns")
@@ -215,3 +795,19 @@ func TestAllowedPatternsWithRegex(t *testing.T) {
 		})
 	}
 }
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"ID":         "id",
+		"Name":       "name",
+		"UserID":     "user_id",
+		"CreatedAt":  "created_at",
+		"HTTPStatus": "http_status",
+		"Address2":   "address2",
+	}
+	for name, want := range tests {
+		if got := snakeCase(name); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -70,8 +70,7 @@ func BenchmarkIsFileInDirectory(b *testing.B) {
 	for _, tc := range testCases {
 		b.Run("path_check", func(b *testing.B) {
 			for i := 0; i < b.N; i++ {
-				// Skip directory checking - ignore functionality removed
-				_ = tc.path // Use path to avoid unused variable
+				_ = isFileInDirectory(tc.path, tc.dir)
 			}
 		})
 	}
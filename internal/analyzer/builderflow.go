@@ -0,0 +1,750 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/MirrexOne/unqueryvet/pkg/config"
+)
+
+// maxBuilderRounds bounds the rounds computing the summaries of the
+// functions of a package, which may call each other
+const maxBuilderRounds = 8
+
+// builderFact summarizes how a function hands SQL builders without columns
+// on to its callers, so that builders created or completed in helpers are
+// followed across functions and packages. Parameters are numbered like the
+// arguments of a static call, the receiver first. Functions without a fact
+// are assumed to use every SQL builder they get and none of the GORM
+// chains, goqu datasets or bun queries, so the fact is only exported when a
+// function does something else.
+type builderFact struct {
+	// Returns is set when the result may be a builder without columns
+	Returns bool
+	// Escapes lists the parameters whose builder may be used without columns
+	Escapes []int
+	// Forwards lists the parameters whose builder may be returned without columns
+	Forwards []int
+	// Completes lists the parameters whose mutable builder is given columns in place
+	Completes []int
+}
+
+// AFact marks builderFact as an analysis.Fact.
+func (*builderFact) AFact() {}
+
+func (f *builderFact) String() string {
+	return fmt.Sprintf("builder returns=%t escapes=%v forwards=%v completes=%v", f.Returns, f.Escapes, f.Forwards, f.Completes)
+}
+
+func (f *builderFact) equal(g *builderFact) bool {
+	return f.Returns == g.Returns && slices.Equal(f.Escapes, g.Escapes) && slices.Equal(f.Forwards, g.Forwards) &&
+		slices.Equal(f.Completes, g.Completes)
+}
+
+// builderStep is the effect of a builder call on the columns of the builder it returns.
+type builderStep int
+
+const (
+	// stepChains keeps the columns of the receiver
+	stepChains builderStep = iota
+	// stepProjects sets explicit columns or replaces the query
+	stepProjects
+	// stepClears resets the columns to *
+	stepClears
+)
+
+// builderDialect describes how a family of SQL builders gets its columns.
+type builderDialect struct {
+	// message is the context of the warning reported for builders without columns
+	message string
+	// typeName is the only type of the package followed as a builder, or
+	// empty when every type of the package is
+	typeName string
+	// mutable builders are changed in place by their methods
+	mutable bool
+	// clonesRoots is set for mutable builders whose methods return a copy
+	// when called on a builder that was not itself returned by a method
+	clonesRoots bool
+	// atUse dialects are reported at the terminal method running the query
+	// rather than where the builder lost its columns. Their builders lack
+	// columns unless given some, wherever the function got them from, except
+	// for parameters, which are left to the callers when the package calls
+	// the function and reported at the terminal methods otherwise.
+	atUse bool
+	// rootParams is set for atUse dialects whose parameters are plain handles
+	// rather than queries under construction: they are reported at the
+	// terminal methods of the function, and callers only passing a handle on
+	// are not reported
+	rootParams bool
+	// terminals are the methods running the query of atUse builders
+	terminals []string
+	// step returns the effect of a call of a function of the dialect; call
+	// is nil when the call expression is unknown
+	step func(pass *analysis.Pass, fn *types.Func, call *ast.CallExpr) builderStep
+}
+
+// selectDialect covers squirrel-like builders, which select every column
+// when Select() is called without columns. They are reported at that call.
+var selectDialect = &builderDialect{
+	message: "empty_select",
+	mutable: true,
+	step: func(_ *analysis.Pass, fn *types.Func, call *ast.CallExpr) builderStep {
+		switch fn.Name() {
+		case selectKeyword:
+			if call != nil && len(call.Args) == 0 {
+				return stepClears
+			}
+			return stepProjects
+		case columnsKeyword, columnKeyword:
+			return stepProjects
+		}
+		return stepChains
+	},
+}
+
+// builderOrigin is where a builder without columns comes from: a value of
+// the function, like an empty Select() call or a call of a helper returning
+// one, or else a parameter.
+type builderOrigin struct {
+	value ssa.Value
+	param int
+}
+
+// builderReport is called for a builder without columns coming from origin
+// and used by use, which is nil when the builder is used otherwise.
+type builderReport func(d *builderDialect, origin ssa.Value, use ssa.CallInstruction)
+
+// builderFlows follows the SQL builders of a package.
+type builderFlows struct {
+	pass  *analysis.Pass
+	cfg   *config.UnqueryvetSettings
+	calls map[token.Pos]*ast.CallExpr
+	// summaries holds the facts of the functions of the package
+	summaries map[*types.Func]*builderFact
+	// entries holds the functions that may be called from outside the package
+	entries map[*ssa.Function]bool
+}
+
+// checkBuilderFlows reports SQL builders, GORM chains, goqu datasets and bun
+// queries used without getting columns on some path: empty Select() calls of
+// builders that are rendered, executed, passed to a function using them or
+// dropped, and terminal methods of the others. Builders are followed through
+// the SSA values of each function, merging the states of branches, and
+// through helpers by their builderFact.
+func checkBuilderFlows(pass *analysis.Pass, funcs []*ssa.Function, files []*ast.File, cfg *config.UnqueryvetSettings) {
+	// Builder packages use their own types freely
+	if builderDialectOf(pass.Pkg, cfg) != nil {
+		return
+	}
+
+	b := &builderFlows{
+		pass:      pass,
+		cfg:       cfg,
+		calls:     callsByLparen(pass.Files),
+		summaries: make(map[*types.Func]*builderFact),
+		entries:   entryPoints(funcs),
+	}
+	var declared []*ssa.Function
+	for _, fn := range funcs {
+		if obj, ok := fn.Object().(*types.Func); ok && b.hasBuilderSignature(fn.Signature) {
+			b.summaries[obj] = &builderFact{}
+			declared = append(declared, fn)
+		}
+	}
+
+	// The rounds stop once no summary changes
+	for range maxBuilderRounds {
+		changed := false
+		for _, fn := range declared {
+			obj := fn.Object().(*types.Func)
+			if fact := b.analyze(fn, nil); !fact.equal(b.summaries[obj]) {
+				b.summaries[obj] = fact
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	for _, fn := range funcs {
+		b.analyze(fn, func(d *builderDialect, origin ssa.Value, use ssa.CallInstruction) {
+			pos := origin.Pos()
+			if d.atUse {
+				expr := b.calls[use.Common().Pos()]
+				if expr == nil || isIgnoredCall(pass, expr, cfg) {
+					return
+				}
+				pos = expr.Pos()
+				if sel, ok := expr.Fun.(*ast.SelectorExpr); ok {
+					pos = sel.Sel.Pos()
+				}
+			} else if call, ok := origin.(*ssa.Call); ok {
+				if expr := b.calls[call.Common().Pos()]; expr != nil {
+					pos = expr.Pos()
+				}
+			}
+			if inFiles(files, pos) {
+				pass.Report(analysis.Diagnostic{
+					Pos:     pos,
+					Message: getDetailedWarningMessage(d.message),
+				})
+			}
+		})
+	}
+
+	for _, fn := range declared {
+		obj := fn.Object().(*types.Func)
+		if fact := b.summaries[obj]; !b.isDefaultFact(fn, fact) {
+			pass.ExportObjectFact(obj, fact)
+		}
+	}
+}
+
+// analyze follows the builders of fn and returns its summary. Builders
+// without columns that are used are passed to report, unless it is nil.
+func (b *builderFlows) analyze(fn *ssa.Function, report builderReport) *builderFact {
+	s := &builderStates{
+		flows:     b,
+		fn:        fn,
+		entry:     b.entries[fn],
+		class:     make(map[ssa.Value]ssa.Value),
+		origins:   make(map[ssa.Value]map[builderOrigin]bool),
+		completed: make(map[ssa.Value]bool),
+	}
+	s.alias()
+	s.propagate()
+	return s.summarize(report)
+}
+
+// builderStates holds the origins of the builders of a function that may
+// lack columns. Values of a mutable builder share the state of every value
+// aliasing the same builder, which is changed in place by its methods.
+type builderStates struct {
+	flows *builderFlows
+	fn    *ssa.Function
+	// entry is set when the callers of the function are unknown
+	entry bool
+	// values lists the builder values of the function
+	values []ssa.Value
+	// class maps a value to another value aliasing the same builder
+	class map[ssa.Value]ssa.Value
+	// origins holds the origins of the builders of each class
+	origins map[ssa.Value]map[builderOrigin]bool
+	// completed holds the classes of mutable builders given columns
+	completed map[ssa.Value]bool
+}
+
+func (s *builderStates) find(v ssa.Value) ssa.Value {
+	for {
+		next, ok := s.class[v]
+		if !ok {
+			return v
+		}
+		v = next
+	}
+}
+
+func (s *builderStates) union(v, w ssa.Value) {
+	if v, w = s.find(v), s.find(w); v != w {
+		s.class[v] = w
+	}
+}
+
+// alias collects the builder values of the function and merges the values
+// of mutable builders that alias each other.
+func (s *builderStates) alias() {
+	b := s.flows
+	for _, param := range s.fn.Params {
+		if b.dialectOfType(param.Type()) != nil {
+			s.values = append(s.values, param)
+		}
+	}
+	for _, block := range s.fn.Blocks {
+		for _, instr := range block.Instrs {
+			v, ok := instr.(ssa.Value)
+			if !ok {
+				continue
+			}
+			d := b.dialectOfType(v.Type())
+			if d == nil {
+				continue
+			}
+			s.values = append(s.values, v)
+			if !d.mutable || !isPointer(v.Type()) {
+				continue
+			}
+			switch v := v.(type) {
+			case *ssa.Phi:
+				for _, edge := range v.Edges {
+					s.union(v, edge)
+				}
+			case *ssa.ChangeType:
+				s.union(v, v.X)
+			case *ssa.Call:
+				if fn, recv := b.callee(v.Common()); fn != nil && recv != nil && b.dialectOfFunc(fn) == d &&
+					types.Identical(recv.Type(), v.Type()) && !(d.clonesRoots && b.isRoot(recv)) {
+					s.union(v, recv)
+				}
+			}
+		}
+	}
+
+	// Columns given to a mutable builder are seen through every alias
+	for _, v := range s.values {
+		if call, ok := v.(*ssa.Call); ok && isPointer(v.Type()) && b.step(call) == stepProjects {
+			if d := b.dialectOfType(v.Type()); d.mutable {
+				s.completed[s.find(v)] = true
+			}
+		}
+	}
+
+	// So are columns given in place by helpers
+	for _, v := range s.values {
+		if !b.dialectOfType(v.Type()).mutable || !isPointer(v.Type()) {
+			continue
+		}
+		for _, ref := range referrers(v) {
+			call, ok := ref.(ssa.CallInstruction)
+			if !ok || call.Common().IsInvoke() {
+				continue
+			}
+			fn, _ := b.callee(call.Common())
+			if fn == nil || b.dialectOfFunc(fn) != nil {
+				continue
+			}
+			if fact := b.fact(fn); fact != nil {
+				for i, arg := range call.Common().Args {
+					if arg == v && slices.Contains(fact.Completes, i) {
+						s.completed[s.find(v)] = true
+					}
+				}
+			}
+		}
+	}
+}
+
+// propagate computes the origins of every builder value until they no longer grow.
+func (s *builderStates) propagate() {
+	for i, param := range s.fn.Params {
+		if s.flows.dialectOfType(param.Type()) != nil {
+			s.add(param, builderOrigin{param: i})
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, v := range s.values {
+			for _, origin := range s.transfer(v) {
+				changed = s.add(v, origin) || changed
+			}
+		}
+	}
+}
+
+func (s *builderStates) add(v ssa.Value, origin builderOrigin) bool {
+	class := s.find(v)
+	if s.origins[class][origin] {
+		return false
+	}
+	if s.origins[class] == nil {
+		s.origins[class] = make(map[builderOrigin]bool)
+	}
+	s.origins[class][origin] = true
+	return true
+}
+
+// transfer returns the origins flowing into v from the values it is computed from.
+func (s *builderStates) transfer(v ssa.Value) []builderOrigin {
+	b := s.flows
+	d := b.dialectOfType(v.Type())
+	var origins []builderOrigin
+	from := func(w ssa.Value) {
+		for origin := range s.origins[s.find(w)] {
+			origins = append(origins, origin)
+		}
+	}
+	// unknown marks v as coming from elsewhere, without columns for atUse dialects
+	unknown := func() {
+		if d.atUse {
+			origins = append(origins, builderOrigin{value: v})
+		}
+	}
+
+	switch v := v.(type) {
+	case *ssa.Parameter, *ssa.FreeVar:
+		// Left to the callers
+	case *ssa.Phi:
+		for _, edge := range v.Edges {
+			from(edge)
+		}
+	case *ssa.ChangeType:
+		from(v.X)
+	case *ssa.Call:
+		common := v.Common()
+		fn, recv := b.callee(common)
+		switch {
+		case fn == nil:
+			unknown()
+		case b.dialectOfFunc(fn) != nil:
+			switch b.step(v) {
+			case stepClears:
+				origins = append(origins, builderOrigin{value: v})
+			case stepChains:
+				if recv != nil && b.dialectOfType(recv.Type()) == d {
+					from(recv)
+				} else {
+					unknown()
+				}
+			}
+		case common.IsInvoke():
+			unknown()
+		default:
+			if fact := b.fact(fn); fact != nil {
+				for _, i := range fact.Forwards {
+					if i < len(common.Args) {
+						from(common.Args[i])
+					}
+				}
+				if fact.Returns {
+					origins = append(origins, builderOrigin{value: v})
+				}
+			}
+		}
+	default:
+		unknown()
+	}
+	return origins
+}
+
+// summarize finds the uses of the builders of the function and returns its summary.
+func (s *builderStates) summarize(report builderReport) *builderFact {
+	fact := &builderFact{}
+	escapes := make(map[int]bool)
+	forwards := make(map[int]bool)
+
+	// escape marks the builders of v as used without columns by use
+	escape := func(d *builderDialect, v ssa.Value, use ssa.CallInstruction) {
+		class := s.find(v)
+		if s.completed[class] {
+			return
+		}
+		for origin := range s.origins[class] {
+			switch {
+			case origin.value != nil:
+				if report != nil {
+					report(d, origin.value, use)
+				}
+			case d.rootParams || d.atUse && s.entry:
+				// Plain handles, and parameters whose callers are out of
+				// sight, are reported here
+				if use != nil && report != nil {
+					report(d, s.fn.Params[origin.param], use)
+				}
+				if d.rootParams {
+					escapes[origin.param] = true
+				}
+			default:
+				escapes[origin.param] = true
+			}
+		}
+	}
+	// ret marks the builders of v as returned to the caller
+	ret := func(v ssa.Value) {
+		class := s.find(v)
+		if s.completed[class] {
+			return
+		}
+		for origin := range s.origins[class] {
+			if origin.value == nil {
+				forwards[origin.param] = true
+			} else {
+				fact.Returns = true
+			}
+		}
+	}
+
+	for _, v := range s.values {
+		d := s.flows.dialectOfType(v.Type())
+		used := false
+		for _, ref := range referrers(v) {
+			switch ref := ref.(type) {
+			case *ssa.DebugRef:
+				continue
+			case *ssa.Phi, *ssa.ChangeType:
+				// Followed as values of their own
+			case *ssa.Return:
+				if s.fn.Signature.Results().Len() == 1 {
+					ret(v)
+				} else if !d.atUse {
+					escape(d, v, nil)
+				}
+			case ssa.CallInstruction:
+				if s.flows.usesBuilder(d, ref.Common(), v) {
+					escape(d, v, ref)
+				}
+			case *ssa.Store, *ssa.MakeClosure, *ssa.Send, *ssa.MapUpdate:
+				// Kept for later, which is not followed
+			default:
+				if !d.atUse {
+					escape(d, v, nil)
+				}
+			}
+			used = true
+		}
+		// A dropped builder never gets columns, a pointer builder lives on in its aliases
+		if _, isCall := v.(*ssa.Call); isCall && !used && !isPointer(v.Type()) && !d.atUse {
+			escape(d, v, nil)
+		}
+	}
+
+	for i := range escapes {
+		fact.Escapes = append(fact.Escapes, i)
+	}
+	for i := range forwards {
+		fact.Forwards = append(fact.Forwards, i)
+	}
+	slices.Sort(fact.Escapes)
+	slices.Sort(fact.Forwards)
+
+	for i, param := range s.fn.Params {
+		if d := s.flows.dialectOfType(param.Type()); d != nil && d.mutable && isPointer(param.Type()) && s.completed[s.find(param)] {
+			fact.Completes = append(fact.Completes, i)
+		}
+	}
+	return fact
+}
+
+// usesBuilder reports whether the call uses the builder v of dialect d as it
+// is. SQL builders are used when rendered, run or passed to a function doing
+// so; builder methods chaining or completing v, and helpers completing or
+// returning it, do not use it. The builders of atUse dialects are only used
+// by their terminal methods and by helpers known to reach one, which report
+// the plain handles of rootParams dialects themselves.
+func (b *builderFlows) usesBuilder(d *builderDialect, common *ssa.CallCommon, v ssa.Value) bool {
+	fn, recv := b.callee(common)
+	if fn == nil {
+		return !d.atUse
+	}
+	if b.dialectOfFunc(fn) != nil {
+		if d.atUse {
+			return recv == v && slices.Contains(d.terminals, fn.Name())
+		}
+		if recv != v {
+			return true
+		}
+		results := fn.Type().(*types.Signature).Results()
+		chained := results.Len() == 1 && b.dialectOfType(results.At(0).Type()) != nil
+		return !chained && fn.Name() != selectKeyword && fn.Name() != columnsKeyword && fn.Name() != columnKeyword
+	}
+	if common.IsInvoke() {
+		return !d.atUse
+	}
+	fact := b.fact(fn)
+	if fact == nil {
+		return !d.atUse
+	}
+	for i, arg := range common.Args {
+		if arg == v && slices.Contains(fact.Escapes, i) {
+			// The helper reports plain handles itself
+			return !d.rootParams || !isPlainHandle(v)
+		}
+	}
+	return false
+}
+
+// isPlainHandle reports whether the builder v was neither built nor merged
+// by the function, like a parameter or a field.
+func isPlainHandle(v ssa.Value) bool {
+	switch v.(type) {
+	case *ssa.Call, *ssa.Phi, *ssa.ChangeType:
+		return false
+	}
+	return true
+}
+
+// entryPoints returns the functions of funcs that may be called from outside
+// the package: exported and anonymous functions, functions used as values
+// and functions the package never calls.
+func entryPoints(funcs []*ssa.Function) map[*ssa.Function]bool {
+	called := make(map[*ssa.Function]bool)
+	referenced := make(map[*ssa.Function]bool)
+	for _, fn := range funcs {
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				var operands [64]*ssa.Value
+				for _, op := range instr.Operands(operands[:0]) {
+					callee, ok := (*op).(*ssa.Function)
+					if !ok {
+						continue
+					}
+					if call, ok := instr.(ssa.CallInstruction); ok && call.Common().Value == callee {
+						called[callee] = true
+					} else {
+						referenced[callee] = true
+					}
+				}
+			}
+		}
+	}
+
+	entries := make(map[*ssa.Function]bool)
+	for _, fn := range funcs {
+		obj, ok := fn.Object().(*types.Func)
+		if !ok || obj.Exported() || !called[fn] || referenced[fn] {
+			entries[fn] = true
+		}
+	}
+	return entries
+}
+
+// callee returns the function or method invoked by common and its receiver.
+func (b *builderFlows) callee(common *ssa.CallCommon) (*types.Func, ssa.Value) {
+	if common.IsInvoke() {
+		return common.Method, common.Value
+	}
+	callee := common.StaticCallee()
+	if callee == nil {
+		return nil, nil
+	}
+	fn, ok := callee.Object().(*types.Func)
+	if !ok {
+		return nil, nil
+	}
+	if callee.Signature.Recv() != nil && len(common.Args) > 0 {
+		return fn, common.Args[0]
+	}
+	return fn, nil
+}
+
+// step returns the effect of a call of a builder function on its result.
+func (b *builderFlows) step(call *ssa.Call) builderStep {
+	fn, _ := b.callee(call.Common())
+	if fn == nil {
+		return stepChains
+	}
+	d := b.dialectOfFunc(fn)
+	if d == nil {
+		return stepChains
+	}
+	return d.step(b.pass, fn, b.calls[call.Common().Pos()])
+}
+
+// isRoot reports whether the builder v was not returned by a method of its
+// dialect, like a parameter, a field or the result of a helper.
+func (b *builderFlows) isRoot(v ssa.Value) bool {
+	switch v := v.(type) {
+	case *ssa.Call:
+		fn, _ := b.callee(v.Common())
+		return fn == nil || b.dialectOfFunc(fn) == nil
+	case *ssa.Phi, *ssa.ChangeType:
+		return false
+	}
+	return true
+}
+
+// fact returns the summary of fn, or nil when it is unknown.
+func (b *builderFlows) fact(fn *types.Func) *builderFact {
+	if fact, ok := b.summaries[fn]; ok {
+		return fact
+	}
+	if fn.Pkg() == nil || builderDialectOf(fn.Pkg(), b.cfg) != nil {
+		return nil
+	}
+	fact := new(builderFact)
+	if !b.pass.ImportObjectFact(fn, fact) {
+		return nil
+	}
+	return fact
+}
+
+// isDefaultFact reports whether fact says what is assumed of fn without a
+// fact: every SQL builder parameter is used, none of the parameters of atUse
+// dialects is, none is returned or completed and no other builder without
+// columns is returned.
+func (b *builderFlows) isDefaultFact(fn *ssa.Function, fact *builderFact) bool {
+	var params []int
+	for i, param := range fn.Params {
+		if d := b.dialectOfType(param.Type()); d != nil && !d.atUse {
+			params = append(params, i)
+		}
+	}
+	return !fact.Returns && len(fact.Forwards) == 0 && len(fact.Completes) == 0 && slices.Equal(fact.Escapes, params)
+}
+
+// hasBuilderSignature reports whether a function has builder parameters or results.
+func (b *builderFlows) hasBuilderSignature(sig *types.Signature) bool {
+	if sig.Recv() != nil && b.dialectOfType(sig.Recv().Type()) != nil {
+		return true
+	}
+	for _, tuple := range []*types.Tuple{sig.Params(), sig.Results()} {
+		for i := range tuple.Len() {
+			if b.dialectOfType(tuple.At(i).Type()) != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dialectOfFunc returns the dialect of the package declaring fn, or nil.
+func (b *builderFlows) dialectOfFunc(fn *types.Func) *builderDialect {
+	if fn.Pkg() == nil {
+		return nil
+	}
+	return builderDialectOf(fn.Pkg(), b.cfg)
+}
+
+// dialectOfType returns the dialect of the builder type t, or of the type t
+// points to, or nil when t is no builder.
+func (b *builderFlows) dialectOfType(t types.Type) *builderDialect {
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+	d := builderDialectOf(named.Obj().Pkg(), b.cfg)
+	if d == nil || (d.typeName != "" && d.typeName != named.Obj().Name()) {
+		return nil
+	}
+	return d
+}
+
+// builderDialectOf returns the dialect of the builders declared in pkg: one
+// of GORM, goqu and bun, or the squirrel-like builders of defaultBuilders and
+// the sql-builders setting. It returns nil for any other package.
+func builderDialectOf(pkg *types.Package, cfg *config.UnqueryvetSettings) *builderDialect {
+	path := pkg.Path()
+	switch {
+	case path == gormPackage:
+		return gormDialect
+	case matchPackage([]string{goquPackage}, path):
+		return goquDialect
+	case matchPackage([]string{bunPackage}, path):
+		return bunDialect
+	case matchPackage(defaultBuilders, path) || matchPackage(cfg.SQLBuilders, path):
+		return selectDialect
+	}
+	return nil
+}
+
+// isPointer reports whether t is a pointer type.
+func isPointer(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Pointer)
+	return ok
+}
+
+// referrers returns the instructions using v.
+func referrers(v ssa.Value) []ssa.Instruction {
+	if refs := v.Referrers(); refs != nil {
+		return *refs
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/MirrexOne/unqueryvet/pkg/config"
+)
+
+// defaultBuilders lists the import paths of the SQL builders checked out of
+// the box, in the format of the ignored-packages setting.
+var defaultBuilders = []string{
+	"github.com/Masterminds/squirrel",
+	"github.com/doug-martin/goqu/...",
+	"github.com/uptrace/bun/...",
+	"github.com/go-jet/jet/...",
+	"github.com/huandu/go-sqlbuilder",
+}
+
+// isBuilderCall reports whether call invokes a function or method declared
+// in a known SQL builder package or one added with the sql-builders setting.
+// Methods promoted from an embedded builder are declared in the builder, so
+// in-house wrappers embedding one are covered as well.
+func isBuilderCall(pass *analysis.Pass, call *ast.CallExpr, cfg *config.UnqueryvetSettings) bool {
+	fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if fn == nil || fn.Pkg() == nil {
+		return false
+	}
+	path := fn.Pkg().Path()
+	return matchPackage(defaultBuilders, path) || matchPackage(cfg.SQLBuilders, path)
+}
+
+// isBuilderMethod reports whether call is a builder call to one of the named methods or functions.
+func isBuilderMethod(pass *analysis.Pass, call *ast.CallExpr, cfg *config.UnqueryvetSettings, names ...string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel == nil {
+		return false
+	}
+	for _, name := range names {
+		if sel.Sel.Name == name {
+			return isBuilderCall(pass, call, cfg)
+		}
+	}
+	return false
+}
+
+// isSQLBuilderSelectStar checks SQL builder method calls for SELECT * usage
+func isSQLBuilderSelectStar(pass *analysis.Pass, call *ast.CallExpr, cfg *config.UnqueryvetSettings) bool {
+	// Check that this is a Select call of a builder
+	if len(call.Args) == 0 || !isBuilderMethod(pass, call, cfg, selectKeyword) {
+		return false
+	}
+
+	// Check Select method arguments for "*" or empty strings
+	for _, arg := range call.Args {
+		// Consider both "*" and empty strings in Select() as problematic
+		if value, ok := constantString(pass, arg); ok && (value == "*" || value == "") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// analyzeSQLBuilders reports Select("*"), Columns("*") and Column("*")
+// calls of SQL builders, like Select().Columns("*"). Empty Select() calls are
+// followed by checkBuilderFlows. With a schema catalog or sensitive columns,
+// the columns of each method chain are checked against the tables it selects
+// from, and stars exposing sensitive columns are reported as such instead of
+// the usual warning.
+func analyzeSQLBuilders(pass *analysis.Pass, file *ast.File, cfg *config.UnqueryvetSettings) {
+	chained := make(map[*ast.CallExpr]bool)
+	sensitiveStars := make(map[*ast.CallExpr]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		// Calls are visited before their receivers, the outermost call of a chain first
+		if (cfg.Catalog != nil || len(cfg.Sensitive.Columns) > 0) && !chained[call] {
+			checkBuilderColumns(pass, call, cfg, chained, sensitiveStars)
+		}
+		switch {
+		case sensitiveStars[call]:
+		case isBuilderMethod(pass, call, cfg, columnsKeyword, columnKeyword) && hasStarInColumns(pass, call),
+			isSQLBuilderSelectStar(pass, call, cfg) && !isIgnoredCall(pass, call, cfg):
+			pass.Report(analysis.Diagnostic{
+				Pos:     call.Pos(),
+				Message: getDetailedWarningMessage("sql_builder"),
+			})
+		}
+		return true
+	})
+}
+
+// hasStarInColumns checks if call arguments contain "*" symbol
+func hasStarInColumns(pass *analysis.Pass, call *ast.CallExpr) bool {
+	for _, arg := range call.Args {
+		if value, ok := constantString(pass, arg); ok && value == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// chainReceiver returns the receiver of a method call, or nil.
+func chainReceiver(call *ast.CallExpr) ast.Expr {
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		return sel.X
+	}
+	return nil
+}
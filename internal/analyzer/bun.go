@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/MirrexOne/unqueryvet/pkg/config"
+)
+
+const (
+	// bunPackage matches the import paths of bun and its subpackages
+	bunPackage = "github.com/uptrace/bun/..."
+	// bunSelectQuery is the qualified name of the bun SELECT query type
+	bunSelectQuery = "github.com/uptrace/bun.SelectQuery"
+)
+
+// bunDialect follows *bun.SelectQuery chains, started by NewSelect of a
+// database, connection or transaction, to the methods running the query,
+// which selects every column unless columns or a model were set. Methods of
+// a query change it in place.
+var bunDialect = &builderDialect{
+	message:   "bun_select",
+	typeName:  "SelectQuery",
+	mutable:   true,
+	atUse:     true,
+	terminals: []string{"Scan", "Exec", "Rows", "ScanAndCount"},
+	step: func(_ *analysis.Pass, fn *types.Func, _ *ast.CallExpr) builderStep {
+		switch fn.Name() {
+		case "Model", columnKeyword, "ColumnExpr":
+			return stepProjects
+		}
+		return stepChains
+	},
+}
+
+// analyzeBunQueries reports Column("*") and ColumnExpr("*") calls of
+// *bun.SelectQuery chains. Queries run without columns or a model are
+// followed by checkBuilderFlows.
+func analyzeBunQueries(pass *analysis.Pass, file *ast.File, cfg *config.UnqueryvetSettings) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || isIgnoredCall(pass, call, cfg) {
+			return true
+		}
+		if name, ok := bunMethod(pass, call); ok && (name == columnKeyword || name == "ColumnExpr") && hasStarInColumns(pass, call) {
+			pass.Report(analysis.Diagnostic{
+				Pos:     call.Pos(),
+				Message: getDetailedWarningMessage("sql_builder"),
+			})
+		}
+		return true
+	})
+}
+
+// bunMethod returns the name of the *bun.SelectQuery method invoked by call.
+func bunMethod(pass *analysis.Pass, call *ast.CallExpr) (string, bool) {
+	if _, ok := call.Fun.(*ast.SelectorExpr); !ok {
+		return "", false
+	}
+	fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if fn == nil || !slices.Contains(qualifiedNames(fn), bunSelectQuery+"."+fn.Name()) {
+		return "", false
+	}
+	return fn.Name(), true
+}
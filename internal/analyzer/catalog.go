@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/MirrexOne/unqueryvet/pkg/config"
+	"github.com/MirrexOne/unqueryvet/pkg/schema"
+)
+
+// catalogs caches the schema catalogs loaded by path, so that drivers
+// running the analyzer on many packages read the schema once per run.
+// Entries are reused only while the schema files are unchanged, so long
+// running drivers like gopls see edits to the schema.
+var catalogs = struct {
+	sync.Mutex
+	entries map[string]catalogEntry
+}{entries: make(map[string]catalogEntry)}
+
+// catalogEntry is a catalog loaded from the schema files described by stamp.
+type catalogEntry struct {
+	stamp   string
+	catalog *schema.Catalog
+}
+
+// loadCatalog returns the catalog read from path, loading it again when the
+// schema files changed since the last load. Errors are not cached, so a
+// schema fixed between runs is picked up.
+func loadCatalog(path string) (*schema.Catalog, error) {
+	catalogs.Lock()
+	defer catalogs.Unlock()
+
+	stamp, err := schemaStamp(path)
+	if err != nil {
+		delete(catalogs.entries, path)
+		return nil, err
+	}
+	if entry, ok := catalogs.entries[path]; ok && entry.stamp == stamp {
+		return entry.catalog, nil
+	}
+	catalog, err := schema.Load(path)
+	if err != nil {
+		delete(catalogs.entries, path)
+		return nil, err
+	}
+	catalogs.entries[path] = catalogEntry{stamp: stamp, catalog: catalog}
+	return catalog, nil
+}
+
+// schemaStamp describes the size and modification time of the schema file
+// at path or, for a migrations directory, of the directory and the files in
+// it.
+func schemaStamp(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	var stamp strings.Builder
+	writeStamp := func(name string, info os.FileInfo) {
+		fmt.Fprintf(&stamp, "%s:%d:%d\n", name, info.Size(), info.ModTime().UnixNano())
+	}
+	writeStamp(path, info)
+	if !info.IsDir() {
+		return stamp.String(), nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := os.Stat(filepath.Join(path, entry.Name()))
+		if err != nil {
+			return "", err
+		}
+		writeStamp(entry.Name(), info)
+	}
+	return stamp.String(), nil
+}
+
+// withCatalog returns cfg with the catalog of its schema setting loaded, or
+// cfg itself when there is no schema or the catalog is already set.
+func withCatalog(cfg *config.UnqueryvetSettings) (*config.UnqueryvetSettings, error) {
+	if cfg.Catalog != nil || cfg.Schema == "" {
+		return cfg, nil
+	}
+	catalog, err := loadCatalog(cfg.Schema)
+	if err != nil {
+		return nil, err
+	}
+	loaded := *cfg
+	loaded.Catalog = catalog
+	return &loaded, nil
+}
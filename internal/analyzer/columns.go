@@ -0,0 +1,317 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/MirrexOne/unqueryvet/internal/sqllex"
+	"github.com/MirrexOne/unqueryvet/pkg/config"
+	"github.com/MirrexOne/unqueryvet/pkg/schema"
+)
+
+// columnRef is a column named by a projection item.
+type columnRef struct {
+	// Offset is the byte offset of the column name in the query
+	Offset int
+	// Qualifier is the table or schema-qualified table of the column as
+	// written, or empty
+	Qualifier string
+	// Name is the column name without quotes
+	Name string
+}
+
+// unknownColumn is a column reference missing from the schema catalog.
+type unknownColumn struct {
+	columnRef
+	// Tables names the tables the column was looked up in
+	Tables string
+	// Suggestion is the closest column of those tables, or empty
+	Suggestion string
+}
+
+// valueKeywords are identifiers that stand for values rather than columns
+// in a projection list.
+var valueKeywords = map[string]bool{
+	"NULL":              true,
+	"TRUE":              true,
+	"FALSE":             true,
+	"DEFAULT":           true,
+	"CURRENT_DATE":      true,
+	"CURRENT_TIME":      true,
+	"CURRENT_TIMESTAMP": true,
+	"CURRENT_USER":      true,
+	"SESSION_USER":      true,
+	"LOCALTIME":         true,
+	"LOCALTIMESTAMP":    true,
+	"ROWNUM":            true,
+}
+
+// findUnknownColumns returns the columns named in the projection lists of
+// query that the catalog does not know. Only plain column references of
+// SELECTs whose tables are all known are checked. Unqualified columns of
+// subqueries may belong to the outer query and are not checked.
+func findUnknownColumns(query string, catalog *schema.Catalog) []unknownColumn {
+	tokens := sqllex.Tokenize(query)
+
+	var unknown []unknownColumn
+	for i, tok := range tokens {
+		if !tok.IsKeyword("SELECT") {
+			continue
+		}
+		context := selectContext(tokens, i)
+		start := skipSelectModifiers(tokens, i+1)
+		items := projectionItems(tokens, start)
+		from := parseFrom(tokens, projectionEnd(start, items))
+		if from == nil {
+			continue
+		}
+		unqualified := context != contextSubquery && context != contextExists
+		for _, item := range items {
+			if ref, ok := projectionColumn(item); ok {
+				if column, ok := checkColumn(ref, from, catalog, unqualified); !ok {
+					unknown = append(unknown, column)
+				}
+			}
+		}
+	}
+	return unknown
+}
+
+// projectionColumn parses a projection item that is a plain column
+// reference like col, t.col or s.t.col, optionally followed by an alias.
+func projectionColumn(item []sqllex.Token) (columnRef, bool) {
+	i := 0
+	for i < len(item) && isNameToken(item[i]) {
+		if i+1 >= len(item) || !item[i+1].IsOperator(".") {
+			break
+		}
+		i += 2
+	}
+	if i >= len(item) || !isNameToken(item[i]) {
+		return columnRef{}, false
+	}
+	name := item[i]
+	if i == 0 && name.Kind == sqllex.Ident && valueKeywords[strings.ToUpper(name.Text)] {
+		return columnRef{}, false
+	}
+
+	switch rest := item[i+1:]; {
+	case len(rest) == 0:
+	case len(rest) == 1 && isAlias(rest[0]):
+	case len(rest) == 2 && rest[0].IsKeyword("AS") && isNameToken(rest[1]):
+	default:
+		return columnRef{}, false
+	}
+
+	ref := columnRef{Offset: name.Pos, Name: name.Name()}
+	if i > 0 {
+		var qualifier strings.Builder
+		for _, tok := range item[:i-1] {
+			qualifier.WriteString(tok.Text)
+		}
+		ref.Qualifier = qualifier.String()
+	}
+	return ref, true
+}
+
+// isNameToken reports whether tok is a bare or quoted identifier.
+func isNameToken(tok sqllex.Token) bool {
+	return tok.Kind == sqllex.Ident || tok.Kind == sqllex.QuotedIdent
+}
+
+// checkColumn looks ref up in the tables of from. It returns false with a
+// description of the column when the tables are known to the catalog and
+// none of them has the column. Unqualified columns are only checked when
+// unqualified is set.
+func checkColumn(ref columnRef, from *fromClause, catalog *schema.Catalog, unqualified bool) (unknownColumn, bool) {
+	refs := from.Tables
+	if ref.Qualifier != "" {
+		table, ok := qualifiedTable(from, ref.Qualifier)
+		if !ok {
+			return unknownColumn{}, true
+		}
+		refs = []tableRef{table}
+	} else if !unqualified {
+		return unknownColumn{}, true
+	}
+
+	if len(refs) == 0 {
+		return unknownColumn{}, true
+	}
+	var (
+		names      []string
+		candidates []string
+	)
+	for _, table := range refs {
+		if table.Name == "" {
+			return unknownColumn{}, true
+		}
+		known, ok := catalog.Table(unquoteName(table.Name))
+		if !ok || known.HasColumn(ref.Name) {
+			return unknownColumn{}, true
+		}
+		names = append(names, known.Name)
+		candidates = append(candidates, known.Columns...)
+	}
+
+	return unknownColumn{
+		columnRef:  ref,
+		Tables:     strings.Join(names, ", "),
+		Suggestion: closestName(ref.Name, candidates),
+	}, false
+}
+
+// closestName returns the candidate closest to name by edit distance,
+// ignoring case, when it is close enough to be a likely typo.
+func closestName(name string, candidates []string) string {
+	var (
+		best    string
+		maxDist = min(len(name)/3+1, 3)
+	)
+	for _, candidate := range candidates {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(candidate)); d <= maxDist {
+			// Later candidates must be strictly closer
+			best, maxDist = candidate, d-1
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	row := make([]int, len(rb)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current := min(row[j]+1, row[j-1]+1, prev+cost)
+			prev, row[j] = row[j], current
+		}
+	}
+	return row[len(rb)]
+}
+
+// unknownColumnMessage returns the message reported for an unknown column.
+func unknownColumnMessage(column unknownColumn) string {
+	name := column.Name
+	if column.Qualifier != "" {
+		name = column.Qualifier + "." + name
+	}
+	message := fmt.Sprintf("column %q does not exist in %s", name, column.Tables)
+	if column.Suggestion != "" {
+		message += fmt.Sprintf(" - did you mean %q?", column.Suggestion)
+	}
+	return message
+}
+
+// builderColumnMethods name builder methods taking column lists, and
+// builderTableMethods those taking the tables the columns are selected from.
+var (
+	builderColumnMethods = []string{selectKeyword, columnsKeyword, columnKeyword}
+	builderTableMethods  = []string{"From", "Table", "Join", "LeftJoin", "RightJoin", "InnerJoin", "FullJoin", "CrossJoin"}
+)
+
+// checkBuilderColumns checks the constant columns passed to the builder
+// calls of the method chain ending in call, like
+// sq.Select("id", "emial").From("users"), against the catalog and the
+// sensitive columns of the tables the chain selects from. The calls of the
+// chain are marked in chained, and those whose star was reported as
+// sensitive data in sensitiveStars.
+func checkBuilderColumns(pass *analysis.Pass, call *ast.CallExpr, cfg *config.UnqueryvetSettings, chained, sensitiveStars map[*ast.CallExpr]bool) {
+	// columnArg is a column argument of a call of the chain
+	type columnArg struct {
+		call *ast.CallExpr
+		expr ast.Expr
+	}
+
+	from := &fromClause{}
+	var columnArgs []columnArg
+	for expr := ast.Expr(call); ; {
+		c, ok := ast.Unparen(expr).(*ast.CallExpr)
+		if !ok {
+			break
+		}
+		chained[c] = true
+		switch {
+		case isBuilderMethod(pass, c, cfg, builderTableMethods...):
+			if len(c.Args) == 0 {
+				from.Tables = append(from.Tables, tableRef{})
+			}
+			for _, arg := range c.Args {
+				value, ok := constantString(pass, arg)
+				tokens := sqllex.Tokenize(value)
+				if !ok || len(tokens) == 0 {
+					from.Tables = append(from.Tables, tableRef{})
+					continue
+				}
+				table, _ := parseTableRef(tokens, 0)
+				from.Tables = append(from.Tables, table)
+			}
+		case isBuilderMethod(pass, c, cfg, builderColumnMethods...):
+			for _, arg := range c.Args {
+				columnArgs = append(columnArgs, columnArg{call: c, expr: arg})
+			}
+		}
+		if expr = chainReceiver(c); expr == nil {
+			break
+		}
+	}
+	if len(from.Tables) == 0 {
+		return
+	}
+
+	patterns := sensitivePatterns(cfg)
+	allowed := isSensitiveAllowed(pass, cfg)
+	for _, arg := range columnArgs {
+		src, ok := constantSource(pass, arg.expr)
+		if !ok {
+			continue
+		}
+		for _, item := range projectionItems(sqllex.Tokenize(src.text), 0) {
+			if star, ok := parseStarItem(item); ok && len(patterns) > 0 {
+				star.From = from
+				if columns := sensitiveStarColumns(star, patterns, cfg); len(columns) > 0 {
+					pass.Report(analysis.Diagnostic{
+						Pos:      src.posAt(star.Offset),
+						Category: sensitiveCategory,
+						Message:  sensitiveStarMessage(star, columns),
+					})
+					sensitiveStars[arg.call] = true
+				}
+				continue
+			}
+			ref, ok := projectionColumn(item)
+			if !ok {
+				continue
+			}
+			if column, ok := sensitiveColumn(ref, from, patterns, cfg); ok && !allowed {
+				pass.Report(analysis.Diagnostic{
+					Pos:      src.posAt(ref.Offset),
+					Category: sensitiveCategory,
+					Message:  sensitiveColumnMessage(column),
+				})
+				continue
+			}
+			if cfg.Catalog == nil {
+				continue
+			}
+			if column, ok := checkColumn(ref, from, cfg.Catalog, true); !ok {
+				pass.Report(analysis.Diagnostic{
+					Pos:     src.posAt(ref.Offset),
+					Message: unknownColumnMessage(column),
+				})
+			}
+		}
+	}
+}
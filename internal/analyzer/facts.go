@@ -0,0 +1,134 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/MirrexOne/unqueryvet/internal/sqllex"
+)
+
+// statementClauses maps the keywords starting an SQL statement to the
+// keywords one of which must follow for a string to be exported as a query,
+// so that words like "DELETE" or "Update" alone are not taken for SQL.
+var statementClauses = map[string][]string{
+	"SELECT": {"FROM"},
+	"INSERT": {"INTO"},
+	"UPDATE": {"SET"},
+	"DELETE": {"FROM"},
+	"MERGE":  {"INTO"},
+	"WITH":   {"SELECT", "INSERT", "UPDATE", "DELETE", "MERGE"},
+}
+
+// sqlQueryFact is exported for package-level string constants and variables
+// whose value is known at analysis time and is an SQL statement, so that packages
+// importing them can check the queries they are used in. Variables are only
+// exported when the declaring package never assigns them.
+type sqlQueryFact struct {
+	Query string
+}
+
+// AFact marks sqlQueryFact as an analysis.Fact.
+func (*sqlQueryFact) AFact() {}
+
+func (f *sqlQueryFact) String() string {
+	return fmt.Sprintf("sql %q", f.Query)
+}
+
+// exportSQLFacts exports a sqlQueryFact for every package-level string
+// constant and unassigned variable declared in files holding an SQL statement.
+// Variables are visited in initialization order, so a variable built from
+// other variables sees their facts.
+func exportSQLFacts(pass *analysis.Pass, files []*ast.File) {
+	export := func(obj types.Object, value ast.Expr) {
+		if !isStringType(obj.Type()) {
+			return
+		}
+		if src, ok := constantSource(pass, value); ok && isSQLStatement(src.text) {
+			pass.ExportObjectFact(obj, &sqlQueryFact{Query: src.text})
+		}
+	}
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.ValueSpec)
+				for i, name := range spec.Names {
+					if obj := pass.TypesInfo.Defs[name]; obj != nil && i < len(spec.Values) {
+						export(obj, spec.Values[i])
+					}
+				}
+			}
+		}
+	}
+
+	assigned := assignedGlobals(pass)
+	for _, init := range pass.TypesInfo.InitOrder {
+		if len(init.Lhs) == 1 && !assigned[init.Lhs[0]] && inFiles(files, init.Lhs[0].Pos()) {
+			export(init.Lhs[0], init.Rhs)
+		}
+	}
+}
+
+// assignedGlobals returns the package-level variables the package assigns
+// or takes the address of outside of their declaration.
+func assignedGlobals(pass *analysis.Pass) map[*types.Var]bool {
+	assigned := make(map[*types.Var]bool)
+	mark := func(expr ast.Expr) {
+		if v := referencedVar(pass, ast.Unparen(expr)); v != nil {
+			assigned[v] = true
+		}
+	}
+
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					mark(lhs)
+				}
+			case *ast.UnaryExpr:
+				if n.Op == token.AND {
+					mark(n.X)
+				}
+			}
+			return true
+		})
+	}
+	return assigned
+}
+
+// isSQLStatement reports whether text starts with an SQL statement keyword,
+// after any opening parentheses, followed later by one of its clauses, like
+// SELECT ... FROM or DELETE FROM.
+func isSQLStatement(text string) bool {
+	var clauses []string
+	for _, tok := range sqllex.Tokenize(text) {
+		switch {
+		case tok.IsComment():
+		case clauses != nil:
+			for _, kw := range clauses {
+				if tok.IsKeyword(kw) {
+					return true
+				}
+			}
+		case tok.IsOperator("("):
+		case tok.Kind == sqllex.Ident:
+			clauses = statementClauses[strings.ToUpper(tok.Text)]
+			if clauses == nil {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return false
+}
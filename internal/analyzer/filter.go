@@ -0,0 +1,109 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/MirrexOne/unqueryvet/pkg/config"
+)
+
+// isIgnoredPackage reports whether the import path of the analyzed package is
+// matched by the ignored-packages setting.
+func isIgnoredPackage(pass *analysis.Pass, cfg *config.UnqueryvetSettings) bool {
+	return matchPackage(cfg.IgnoredPackages, pass.Pkg.Path())
+}
+
+// matchPackage reports whether the import path is matched by one of patterns.
+// Patterns may use * and ? wildcards, and a trailing /... also matches every
+// package below.
+func matchPackage(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+			if matchGlob(prefix, path) || matchGlob(prefix+"/*", path) {
+				return true
+			}
+			continue
+		}
+		if matchGlob(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// includedFiles returns the files of the package that are not matched by
+// the exclude-files setting.
+func includedFiles(pass *analysis.Pass, cfg *config.UnqueryvetSettings) []*ast.File {
+	if len(cfg.ExcludeFiles) == 0 {
+		return pass.Files
+	}
+	var files []*ast.File
+	for _, file := range pass.Files {
+		if !isExcludedFile(pass.Fset.File(file.Pos()).Name(), cfg.ExcludeFiles) {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// isExcludedFile reports whether path is matched by one of patterns.
+// A pattern ending in a slash names a directory and matches every file below
+// it. Other patterns are globs matched against the file name and against
+// every trailing part of the path, so that *_test.go and db/migrations/*.go
+// match wherever the module lives.
+func isExcludedFile(path string, patterns []string) bool {
+	path = filepath.ToSlash(path)
+	for _, pattern := range patterns {
+		if dir, ok := strings.CutSuffix(pattern, "/"); ok {
+			if isFileInDirectory(path, dir) {
+				return true
+			}
+			continue
+		}
+		for rest := path; ; {
+			if matchGlob(pattern, rest) {
+				return true
+			}
+			slash := strings.IndexByte(rest, '/')
+			if slash < 0 {
+				break
+			}
+			rest = rest[slash+1:]
+		}
+	}
+	return false
+}
+
+// isFileInDirectory reports whether the slash-separated path lies below a
+// directory named dir, which may itself span several path elements.
+func isFileInDirectory(path, dir string) bool {
+	dir = strings.Trim(dir, "/")
+	if dir == "" {
+		return false
+	}
+	for i := 0; ; {
+		idx := strings.Index(path[i:], dir)
+		if idx < 0 {
+			return false
+		}
+		start, end := i+idx, i+idx+len(dir)
+		if (start == 0 || path[start-1] == '/') && end < len(path) && path[end] == '/' {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+// inFiles reports whether pos lies in one of files.
+func inFiles(files []*ast.File, pos token.Pos) bool {
+	for _, file := range files {
+		if pos >= file.FileStart && pos < file.FileEnd {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,232 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+
+	"github.com/MirrexOne/unqueryvet/internal/sqllex"
+	"github.com/MirrexOne/unqueryvet/pkg/config"
+	"github.com/MirrexOne/unqueryvet/pkg/schema"
+)
+
+// plainColumn matches column names that can be written without quotes.
+var plainColumn = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// reservedWords are keywords that need quotes when used as column names.
+// Quoting differs between databases, so stars of tables with such columns
+// are not expanded.
+var reservedWords = map[string]bool{
+	"ALL": true, "AND": true, "ANY": true, "AS": true, "ASC": true, "BETWEEN": true,
+	"BY": true, "CASE": true, "CHECK": true, "COLUMN": true, "CONSTRAINT": true,
+	"CREATE": true, "CROSS": true, "DEFAULT": true, "DESC": true, "DISTINCT": true,
+	"ELSE": true, "END": true, "EXCEPT": true, "EXISTS": true, "FETCH": true,
+	"FOR": true, "FOREIGN": true, "FROM": true, "FULL": true, "GROUP": true,
+	"HAVING": true, "IN": true, "INNER": true, "INTERSECT": true, "INTO": true,
+	"IS": true, "JOIN": true, "KEY": true, "LEFT": true, "LIKE": true, "LIMIT": true,
+	"NATURAL": true, "NOT": true, "NULL": true, "OFFSET": true, "ON": true, "OR": true,
+	"ORDER": true, "OUTER": true, "PRIMARY": true, "REFERENCES": true, "RIGHT": true,
+	"SELECT": true, "TABLE": true, "THEN": true, "TO": true, "UNION": true,
+	"UNIQUE": true, "USER": true, "USING": true, "VALUES": true, "WHEN": true,
+	"WHERE": true, "WINDOW": true, "WITH": true,
+}
+
+// plainColumns reports whether columns can all be written without quotes.
+func plainColumns(columns []string) bool {
+	for _, column := range columns {
+		if !plainColumn.MatchString(column) || reservedWords[strings.ToUpper(column)] {
+			return false
+		}
+	}
+	return true
+}
+
+// starFixes returns a fix replacing star with explicit columns, when the star
+// is written verbatim in a string literal of the package. The columns are
+// those of the struct the rows of the query are scanned into by use, or else
+// those of the selected tables when every table is known to the schema catalog.
+func starFixes(pass *analysis.Pass, src sqlSource, star selectStar, use ast.Node, cfg *config.UnqueryvetSettings) []analysis.SuggestedFix {
+	if star.Context == contextExists {
+		return nil
+	}
+	var (
+		columns []string
+		origin  string
+	)
+	if call, ok := use.(*ast.CallExpr); ok && isScannedStar(src.text, star) {
+		if scanned, name, ok := scanColumns(pass, call); ok && plainColumns(scanned) {
+			columns, origin = qualifyColumns(star.Qualifier, scanned), "the fields of "+name
+		}
+	}
+	if columns == nil && cfg.Catalog != nil {
+		if selected, tables, ok := starColumns(star, cfg.Catalog); ok {
+			columns, origin = selected, "the columns of "+strings.Join(tables, ", ")
+		}
+	}
+	if columns == nil {
+		return nil
+	}
+	pos, end, ok := literalRange(pass, src, star.Start, star.Offset+1)
+	if !ok {
+		return nil
+	}
+
+	item := "*"
+	if star.Qualifier != "" {
+		item = star.Qualifier + ".*"
+	}
+	return []analysis.SuggestedFix{{
+		Message: "Replace " + item + " with " + origin,
+		TextEdits: []analysis.TextEdit{{
+			Pos:     pos,
+			End:     end,
+			NewText: []byte(strings.Join(columns, ", ")),
+		}},
+	}}
+}
+
+// starColumns returns the columns star stands for, qualified as needed to
+// keep them unambiguous, together with the names of the tables they belong to.
+func starColumns(star selectStar, catalog *schema.Catalog) (columns, tables []string, ok bool) {
+	if star.From == nil || len(star.From.Tables) == 0 {
+		return nil, nil, false
+	}
+
+	refs := star.From.Tables
+	if star.Qualifier != "" {
+		table, found := qualifiedTable(star.From, star.Qualifier)
+		if !found {
+			return nil, nil, false
+		}
+		refs = []tableRef{table}
+	} else if len(refs) > 1 && star.From.Merged {
+		return nil, nil, false
+	}
+
+	for _, ref := range refs {
+		if ref.Name == "" {
+			return nil, nil, false
+		}
+		table, found := catalog.Table(unquoteName(ref.Name))
+		if !found || len(table.Columns) == 0 {
+			return nil, nil, false
+		}
+		prefix := ""
+		switch {
+		case star.Qualifier != "":
+			prefix = star.Qualifier + "."
+		case len(refs) > 1:
+			prefix = ref.ref() + "."
+		}
+		if !plainColumns(table.Columns) || slices.ContainsFunc(table.Columns, table.NeedsQuotes) {
+			return nil, nil, false
+		}
+		for _, column := range table.Columns {
+			columns = append(columns, prefix+column)
+		}
+		tables = append(tables, table.Name)
+	}
+	return columns, tables, true
+}
+
+// isScannedStar reports whether the columns of star in query are the
+// columns of the rows that are scanned: it is the only item of the outermost
+// SELECT, over a single table unless qualified, in a query without set
+// operations.
+func isScannedStar(query string, star selectStar) bool {
+	if star.Context != contextTopLevel || !star.Sole || star.From == nil {
+		return false
+	}
+	if star.Qualifier == "" && len(star.From.Tables) != 1 {
+		return false
+	}
+	tokens := sqllex.Tokenize(query)
+	for j := range tokens {
+		if isSetOperator(tokens, j) {
+			return false
+		}
+	}
+	return true
+}
+
+// qualifyColumns returns columns prefixed with qualifier, if any.
+func qualifyColumns(qualifier string, columns []string) []string {
+	if qualifier == "" {
+		return columns
+	}
+	qualified := make([]string, len(columns))
+	for i, column := range columns {
+		qualified[i] = qualifier + "." + column
+	}
+	return qualified
+}
+
+// qualifiedTable returns the table of from that the qualifier of a star
+// like u.* or public.users.* refers to: the table of that alias, or an
+// unaliased table of that name.
+func qualifiedTable(from *fromClause, qualifier string) (tableRef, bool) {
+	name := strings.ToLower(unquoteName(qualifier))
+	var (
+		found tableRef
+		count int
+	)
+	for _, table := range from.Tables {
+		var match bool
+		if table.Alias != "" {
+			match = strings.ToLower(unquoteName(table.Alias)) == name
+		} else if table.Name != "" {
+			written := strings.ToLower(unquoteName(table.Name))
+			match = written == name || strings.HasSuffix(written, "."+name)
+		}
+		if match {
+			found, count = table, count+1
+		}
+	}
+	return found, count == 1
+}
+
+// literalRange returns the source range of text[i:j] when those bytes are
+// written verbatim, without escape sequences, in a single string literal of
+// the analyzed package, so that a text edit can replace them.
+func literalRange(pass *analysis.Pass, src sqlSource, i, j int) (token.Pos, token.Pos, bool) {
+	if i < 0 || j > len(src.pos) || i >= j {
+		return token.NoPos, token.NoPos, false
+	}
+	pos, end := src.pos[i], src.pos[j-1]+1
+	if !pos.IsValid() || int(end-pos) != j-i {
+		return token.NoPos, token.NoPos, false
+	}
+
+	lit := enclosingLiteral(pass, pos)
+	if lit == nil || end > lit.End() {
+		return token.NoPos, token.NoPos, false
+	}
+	offset := int(pos - lit.Pos())
+	if lit.Value[offset:offset+j-i] != src.text[i:j] {
+		return token.NoPos, token.NoPos, false
+	}
+	return pos, end, true
+}
+
+// enclosingLiteral returns the string literal of the analyzed package that
+// contains pos, or nil.
+func enclosingLiteral(pass *analysis.Pass, pos token.Pos) *ast.BasicLit {
+	for _, file := range pass.Files {
+		if pos < file.FileStart || pos >= file.FileEnd {
+			continue
+		}
+		path, _ := astutil.PathEnclosingInterval(file, pos, pos)
+		if len(path) > 0 {
+			if lit, ok := path[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				return lit
+			}
+		}
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,564 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/MirrexOne/unqueryvet/internal/sqllex"
+	"github.com/MirrexOne/unqueryvet/pkg/config"
+)
+
+const (
+	// maxFlowVariants bounds the number of alternative texts tracked for a single value
+	maxFlowVariants = 16
+	// maxFlowDepth bounds the length of the def-use chains followed for a single value
+	maxFlowDepth = 32
+)
+
+// builderWriteMethods are the methods of strings.Builder and bytes.Buffer that append text.
+var builderWriteMethods = map[string]bool{
+	"WriteString": true,
+	"WriteByte":   true,
+	"WriteRune":   true,
+	"Write":       true,
+}
+
+// checkSQLFlows rebuilds the possible text of every string passed to a call
+// in files, following local variables, concatenations, branches and
+// strings.Builder or bytes.Buffer contents in the SSA form of each function,
+// and checks the resulting queries. Findings inside literals are reported at
+// the literal and merged with the diagnostics of the literal itself.
+func checkSQLFlows(pass *analysis.Pass, funcs []*ssa.Function, files []*ast.File, cfg *config.UnqueryvetSettings) {
+	calls := callsByLparen(files)
+
+	for _, fn := range funcs {
+		if !inFiles(files, fn.Pos()) {
+			continue
+		}
+		eval := newFlowEvaluator(pass, cfg, fn, calls)
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				site, ok := instr.(ssa.CallInstruction)
+				if !ok {
+					continue
+				}
+				call := calls[site.Common().Pos()]
+				if call == nil || !isCheckedCall(pass, call, cfg) {
+					continue
+				}
+				exprs := argumentExprs(site.Common(), call)
+				for i, arg := range site.Common().Args {
+					if !isStringType(arg.Type()) {
+						continue
+					}
+					// Arguments resolved without flow analysis are checked where the call is inspected
+					if _, isConst := arg.(*ssa.Const); isConst {
+						continue
+					}
+					if _, resolved := constantSource(pass, exprs[i]); resolved {
+						continue
+					}
+					for _, src := range eval.eval(arg, exprs[i], 0) {
+						checkFlowSource(pass, src, call, cfg)
+					}
+				}
+				if variants, ok := eval.formatted(site.Common(), 0); ok {
+					for _, src := range variants {
+						checkFlowSource(pass, src, call, cfg)
+					}
+				}
+			}
+		}
+	}
+}
+
+// checkFlowSource checks a query that reaches call. Text without a known
+// position is reported at the call.
+func checkFlowSource(pass *analysis.Pass, src sqlSource, call *ast.CallExpr, cfg *config.UnqueryvetSettings) {
+	if src.start == token.NoPos {
+		src.start = call.Pos()
+	}
+	checkSQLSource(pass, src, call, cfg)
+}
+
+// argumentExprs returns the expression written for each argument of an SSA
+// call, or nil where it cannot be matched, e.g. for a packed variadic slice.
+func argumentExprs(common *ssa.CallCommon, call *ast.CallExpr) []ast.Expr {
+	exprs := make([]ast.Expr, len(common.Args))
+	sig := common.Signature()
+	offset := 0
+	if !common.IsInvoke() && sig.Recv() != nil {
+		// The receiver of a static method call is passed as the first argument
+		offset = 1
+	}
+	fixed := len(common.Args) - offset
+	if sig.Variadic() {
+		fixed--
+	}
+	if fixed < 0 || len(call.Args) < fixed || (!sig.Variadic() && len(call.Args) != fixed) {
+		return exprs
+	}
+	for i := range fixed {
+		exprs[offset+i] = call.Args[i]
+	}
+	return exprs
+}
+
+// callsByLparen indexes the call expressions of files by the position of
+// their opening parenthesis, which is the position SSA calls report.
+func callsByLparen(files []*ast.File) map[token.Pos]*ast.CallExpr {
+	calls := make(map[token.Pos]*ast.CallExpr)
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				calls[call.Lparen] = call
+			}
+			return true
+		})
+	}
+	return calls
+}
+
+// flowEvaluator rebuilds the text of string values of one function.
+type flowEvaluator struct {
+	pass  *analysis.Pass
+	cfg   *config.UnqueryvetSettings
+	fn    *ssa.Function
+	calls map[token.Pos]*ast.CallExpr
+	// binaries indexes the binary expressions of the function by operator position
+	binaries map[token.Pos]*ast.BinaryExpr
+	// constants maps the value of every outermost constant string expression
+	// written in the function to the expressions written with that value, to
+	// recover the source positions SSA constants do not carry
+	constants map[string][]ast.Expr
+	// assigned maps local variables to the constant string expressions assigned to them
+	assigned map[*types.Var][]ast.Expr
+	visiting map[ssa.Value]bool
+}
+
+func newFlowEvaluator(pass *analysis.Pass, cfg *config.UnqueryvetSettings, fn *ssa.Function, calls map[token.Pos]*ast.CallExpr) *flowEvaluator {
+	eval := &flowEvaluator{
+		pass:      pass,
+		cfg:       cfg,
+		fn:        fn,
+		calls:     calls,
+		binaries:  make(map[token.Pos]*ast.BinaryExpr),
+		constants: make(map[string][]ast.Expr),
+		assigned:  make(map[*types.Var][]ast.Expr),
+		visiting:  make(map[ssa.Value]bool),
+	}
+	if syntax := fn.Syntax(); syntax != nil {
+		ast.Inspect(syntax, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				eval.recordAssignments(n.Lhs, n.Rhs)
+			case *ast.ValueSpec:
+				eval.recordAssignments(identExprs(n.Names), n.Values)
+			}
+			expr, ok := n.(ast.Expr)
+			if !ok {
+				return true
+			}
+			if binary, ok := expr.(*ast.BinaryExpr); ok {
+				eval.binaries[binary.OpPos] = binary
+			}
+			value, ok := constantString(pass, expr)
+			if !ok {
+				return true
+			}
+			eval.constants[value] = append(eval.constants[value], expr)
+			return false
+		})
+	}
+	return eval
+}
+
+// recordAssignments records the constant strings assigned to local variables.
+func (e *flowEvaluator) recordAssignments(lhs, rhs []ast.Expr) {
+	if len(lhs) != len(rhs) {
+		return
+	}
+	for i, target := range lhs {
+		ident, ok := target.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		v, ok := e.pass.TypesInfo.ObjectOf(ident).(*types.Var)
+		if !ok {
+			continue
+		}
+		if _, ok := constantString(e.pass, rhs[i]); ok {
+			e.assigned[v] = append(e.assigned[v], rhs[i])
+		}
+	}
+}
+
+// assignedConstant returns the only constant expression with the given value
+// assigned to the local variable expr refers to, or nil.
+func (e *flowEvaluator) assignedConstant(expr ast.Expr, value string) ast.Expr {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	v, ok := e.pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok {
+		return nil
+	}
+	var found ast.Expr
+	for _, assigned := range e.assigned[v] {
+		if written, _ := constantString(e.pass, assigned); written == value {
+			if found != nil {
+				return nil
+			}
+			found = assigned
+		}
+	}
+	return found
+}
+
+// eval returns the possible texts of v. Parts that cannot be determined
+// statically are represented by sqllex.Placeholder. When known, expr is the
+// expression v was computed from and provides the positions of constant parts.
+func (e *flowEvaluator) eval(v ssa.Value, expr ast.Expr, depth int) []sqlSource {
+	if depth > maxFlowDepth || e.visiting[v] {
+		return []sqlSource{opaqueSource()}
+	}
+	e.visiting[v] = true
+	defer delete(e.visiting, v)
+
+	switch v := v.(type) {
+	case *ssa.Const:
+		return e.constSources(v, expr)
+	case *ssa.BinOp:
+		if v.Op == token.ADD && isStringType(v.Type()) {
+			var x, y ast.Expr
+			if binary := e.binaries[v.Pos()]; binary != nil {
+				x, y = binary.X, binary.Y
+			}
+			return concatSources(e.eval(v.X, x, depth+1), e.eval(v.Y, y, depth+1))
+		}
+	case *ssa.Phi:
+		var variants []sqlSource
+		for _, edge := range v.Edges {
+			variants = append(variants, e.eval(edge, nil, depth+1)...)
+		}
+		return limitSources(variants)
+	case *ssa.ChangeType:
+		return e.eval(v.X, nil, depth+1)
+	case *ssa.UnOp:
+		if global, ok := v.X.(*ssa.Global); ok && v.Op == token.MUL {
+			if obj, ok := global.Object().(*types.Var); ok {
+				if src, ok := globalSource(e.pass, obj, expr); ok {
+					return []sqlSource{src}
+				}
+			}
+		}
+	case *ssa.Call:
+		if recv := builderStringReceiver(v); recv != nil {
+			return e.builderContents(recv, v, depth+1)
+		}
+		if variants, ok := e.formatted(&v.Call, depth+1); ok {
+			return variants
+		}
+	}
+	return []sqlSource{opaqueSource()}
+}
+
+// constSources returns the text of an SSA constant, with source positions
+// when the expression it comes from is known: given as expr or assigned to
+// the variable expr refers to. Otherwise the text is attributed to every
+// expression written with its value in the function, so that findings land on
+// the literals, which are checked on their own, rather than on the call.
+func (e *flowEvaluator) constSources(c *ssa.Const, expr ast.Expr) []sqlSource {
+	if c.Value == nil || c.Value.Kind() != constant.String {
+		return []sqlSource{opaqueSource()}
+	}
+	value := constant.StringVal(c.Value)
+	for _, expr := range []ast.Expr{expr, e.assignedConstant(expr, value)} {
+		if src, ok := e.writtenSource(expr, value); ok {
+			return []sqlSource{src}
+		}
+	}
+
+	var variants []sqlSource
+	for _, expr := range e.constants[value] {
+		if src, ok := e.writtenSource(expr, value); ok {
+			variants = append(variants, src)
+		}
+	}
+	if len(variants) == 0 {
+		return []sqlSource{valueSource(value, token.NoPos)}
+	}
+	return limitSources(variants)
+}
+
+// writtenSource returns the text of expr with its positions when expr is a
+// constant string expression with the given value.
+func (e *flowEvaluator) writtenSource(expr ast.Expr, value string) (sqlSource, bool) {
+	if written, ok := constantString(e.pass, expr); !ok || written != value {
+		return sqlSource{}, false
+	}
+	return constantSource(e.pass, expr)
+}
+
+// formatted returns the possible texts formatted by a call to a printf-like
+// function, with the possible texts of its arguments inserted.
+func (e *flowEvaluator) formatted(common *ssa.CallCommon, depth int) ([]sqlSource, bool) {
+	callee := common.StaticCallee()
+	if callee == nil {
+		return nil, false
+	}
+	fn, _ := callee.Object().(*types.Func)
+	param, ok := printfFormatIndex(e.pass, fn)
+	if !ok || isIgnoredFunc(fn, e.cfg) {
+		return nil, false
+	}
+	idx := param
+	if callee.Signature.Recv() != nil {
+		// The receiver is passed as the first argument
+		idx++
+	}
+	if idx+1 >= len(common.Args) {
+		return nil, false
+	}
+
+	call := e.calls[common.Pos()]
+	var exprs []ast.Expr
+	if call != nil {
+		exprs = argumentExprs(common, call)
+	}
+	exprAt := func(i int) ast.Expr {
+		if i < len(exprs) {
+			return exprs[i]
+		}
+		return nil
+	}
+
+	var args [][]sqlSource
+	for n, arg := range packedArgs(common.Args[idx+1]) {
+		var hint ast.Expr
+		if call != nil && !call.Ellipsis.IsValid() && param+1+n < len(call.Args) {
+			hint = call.Args[param+1+n]
+		}
+		var variants []sqlSource
+		if arg != nil && isStringType(arg.Type()) {
+			variants = e.eval(arg, hint, depth)
+		}
+		args = append(args, variants)
+	}
+
+	var variants []sqlSource
+	for _, format := range e.eval(common.Args[idx], exprAt(idx), depth) {
+		variants = append(variants, expandFormat(format, args)...)
+	}
+	return limitSources(variants), true
+}
+
+// packedArgs returns the values packed into the variadic slice v, with nil
+// for values that cannot be determined.
+func packedArgs(v ssa.Value) []ssa.Value {
+	slice, ok := v.(*ssa.Slice)
+	if !ok {
+		return nil
+	}
+	alloc, ok := slice.X.(*ssa.Alloc)
+	if !ok {
+		return nil
+	}
+	array, ok := alloc.Type().(*types.Pointer).Elem().Underlying().(*types.Array)
+	if !ok {
+		return nil
+	}
+
+	values := make([]ssa.Value, array.Len())
+	for _, ref := range *alloc.Referrers() {
+		addr, ok := ref.(*ssa.IndexAddr)
+		if !ok {
+			continue
+		}
+		index, ok := addr.Index.(*ssa.Const)
+		if !ok {
+			continue
+		}
+		i := index.Int64()
+		if i < 0 || i >= int64(len(values)) {
+			continue
+		}
+		for _, store := range *addr.Referrers() {
+			if store, ok := store.(*ssa.Store); ok && store.Addr == addr {
+				values[i] = store.Val
+				if iface, ok := store.Val.(*ssa.MakeInterface); ok {
+					values[i] = iface.X
+				}
+			}
+		}
+	}
+	return values
+}
+
+// builderContents returns the texts written to the strings.Builder or
+// bytes.Buffer recv before the instruction at, one for each path through the
+// function, so that writes of exclusive branches never end up in the same
+// text. The texts reaching each block are collected until they no longer
+// grow, loops adding one variant per iteration up to maxFlowVariants.
+func (e *flowEvaluator) builderContents(recv ssa.Value, at ssa.Instruction, depth int) []sqlSource {
+	written := make(map[*ssa.Call][]sqlSource)
+	// through returns the texts after the writes of block before stop
+	through := func(block *ssa.BasicBlock, entry []sqlSource, stop ssa.Instruction) []sqlSource {
+		contents := entry
+		for _, instr := range block.Instrs {
+			if instr == stop {
+				break
+			}
+			call, ok := instr.(*ssa.Call)
+			if !ok || !isBuilderWrite(call, recv) {
+				continue
+			}
+			texts, ok := written[call]
+			if !ok {
+				texts = []sqlSource{opaqueSource()}
+				if arg := call.Call.Args[1]; isStringType(arg.Type()) {
+					var expr ast.Expr
+					if c := e.calls[call.Pos()]; c != nil && len(c.Args) == 1 {
+						expr = c.Args[0]
+					}
+					texts = e.eval(arg, expr, depth)
+				}
+				written[call] = texts
+			}
+			contents = concatSources(contents, texts)
+		}
+		return contents
+	}
+	// entry returns the texts reaching block from its predecessors
+	ends := make(map[*ssa.BasicBlock][]sqlSource)
+	entry := func(block *ssa.BasicBlock) []sqlSource {
+		if block.Index == 0 {
+			return []sqlSource{valueSource("", token.NoPos)}
+		}
+		var contents []sqlSource
+		for _, pred := range block.Preds {
+			contents = appendSources(contents, ends[pred])
+		}
+		return contents
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, block := range e.fn.Blocks {
+			end := appendSources(ends[block], through(block, entry(block), nil))
+			if len(end) != len(ends[block]) {
+				ends[block] = end
+				changed = true
+			}
+		}
+	}
+	return through(at.Block(), entry(at.Block()), at)
+}
+
+// appendSources appends to variants the texts of added it lacks, keeping
+// the number of alternatives bounded.
+func appendSources(variants, added []sqlSource) []sqlSource {
+	for _, src := range added {
+		if len(variants) >= maxFlowVariants {
+			break
+		}
+		if !slices.ContainsFunc(variants, src.equal) {
+			variants = append(variants, src)
+		}
+	}
+	return variants
+}
+
+// builderStringReceiver returns the receiver of a (*strings.Builder).String
+// or (*bytes.Buffer).String call, or nil for any other call.
+func builderStringReceiver(call *ssa.Call) ssa.Value {
+	callee := call.Call.StaticCallee()
+	if callee == nil || callee.Name() != "String" || !isTextBuffer(callee.Signature.Recv()) {
+		return nil
+	}
+	return call.Call.Args[0]
+}
+
+// isBuilderWrite reports whether call appends text to the buffer recv.
+func isBuilderWrite(call *ssa.Call, recv ssa.Value) bool {
+	callee := call.Call.StaticCallee()
+	if callee == nil || !builderWriteMethods[callee.Name()] || !isTextBuffer(callee.Signature.Recv()) {
+		return false
+	}
+	return len(call.Call.Args) == 2 && call.Call.Args[0] == recv
+}
+
+// isTextBuffer reports whether recv is a *strings.Builder or *bytes.Buffer receiver.
+func isTextBuffer(recv *types.Var) bool {
+	if recv == nil {
+		return false
+	}
+	ptr, ok := recv.Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	path, name := named.Obj().Pkg().Path(), named.Obj().Name()
+	return path == "strings" && name == "Builder" || path == "bytes" && name == "Buffer"
+}
+
+// opaqueSource stands for text that is unknown at analysis time.
+func opaqueSource() sqlSource {
+	return valueSource(sqllex.Placeholder, token.NoPos)
+}
+
+// concatSources returns every concatenation of a text from left with a text from right.
+func concatSources(left, right []sqlSource) []sqlSource {
+	var variants []sqlSource
+	for _, l := range left {
+		for _, r := range right {
+			variants = append(variants, l.concat(r))
+		}
+	}
+	return limitSources(variants)
+}
+
+// limitSources keeps the number of tracked alternatives bounded.
+func limitSources(variants []sqlSource) []sqlSource {
+	if len(variants) > maxFlowVariants {
+		return variants[:maxFlowVariants]
+	}
+	return variants
+}
+
+// identExprs converts identifiers to expressions.
+func identExprs(idents []*ast.Ident) []ast.Expr {
+	exprs := make([]ast.Expr, len(idents))
+	for i, ident := range idents {
+		exprs[i] = ident
+	}
+	return exprs
+}
+
+// constantString returns the value of a constant string expression.
+func constantString(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	if expr == nil {
+		return "", false
+	}
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+// isStringType reports whether t is a string type.
+func isStringType(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
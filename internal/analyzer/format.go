@@ -0,0 +1,152 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/printf"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// textVerbs are the verbs that insert an argument into a format string as
+// text. Arguments printed with any other verb are opaque.
+const textVerbs = "sv"
+
+// printfFormatIndex returns the index of the format parameter of fn when fn
+// formats like fmt.Printf, fmt.Sprintf or fmt.Fprintf, including wrappers of
+// those. Functions building errors, such as fmt.Errorf, are not considered.
+func printfFormatIndex(pass *analysis.Pass, fn *types.Func) (int, bool) {
+	result, ok := pass.ResultOf[printf.Analyzer].(*printf.Result)
+	if !ok || fn == nil || result.Kind(fn) != printf.KindPrintf {
+		return 0, false
+	}
+
+	sig := fn.Type().(*types.Signature)
+	if results := sig.Results(); results.Len() == 1 && isErrorType(results.At(0).Type()) {
+		return 0, false
+	}
+	params := sig.Params()
+	if !sig.Variadic() || params.Len() < 2 || !isStringType(params.At(params.Len()-2).Type()) {
+		return 0, false
+	}
+	return params.Len() - 2, true
+}
+
+// formatSource returns the text a call to a printf-like function formats,
+// with the constant arguments inserted into the format. Arguments that are
+// not constant are opaque.
+func formatSource(pass *analysis.Pass, call *ast.CallExpr) ([]sqlSource, bool) {
+	fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	idx, ok := printfFormatIndex(pass, fn)
+	if !ok || idx >= len(call.Args) {
+		return nil, false
+	}
+	format, ok := constantSource(pass, call.Args[idx])
+	if !ok {
+		return nil, false
+	}
+
+	var args [][]sqlSource
+	if !call.Ellipsis.IsValid() {
+		for _, arg := range call.Args[idx+1:] {
+			var variants []sqlSource
+			if src, ok := constantSource(pass, arg); ok {
+				variants = []sqlSource{src}
+			}
+			args = append(args, variants)
+		}
+	}
+	return expandFormat(format, args), true
+}
+
+// expandFormat returns the possible texts of format with every %s and %v
+// verb replaced by the possible texts of its argument. Verbs whose argument
+// is unknown, and all other verbs, become sqllex.Placeholder.
+func expandFormat(format sqlSource, args [][]sqlSource) []sqlSource {
+	text := format.text
+	variants := []sqlSource{valueSource("", format.start)}
+	argNum, literal := 0, 0
+
+	flush := func(end int) {
+		if end > literal {
+			variants = concatSources(variants, []sqlSource{format.slice(literal, end)})
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] != '%' {
+			continue
+		}
+		flush(i)
+		if i+1 < len(text) && text[i+1] == '%' {
+			// %% is a literal percent sign
+			literal = i + 1
+			i++
+			continue
+		}
+
+		// Skip flags, width, precision and explicit argument indexes up to the verb
+		j := i + 1
+		for ; j < len(text); j++ {
+			c := text[j]
+			if c == '[' {
+				end := strings.IndexByte(text[j:], ']')
+				if end < 0 {
+					break
+				}
+				if n, ok := parseArgIndex(text[j+1 : j+end]); ok {
+					argNum = n - 1
+				}
+				j += end
+				continue
+			}
+			if c == '*' {
+				argNum++
+				continue
+			}
+			if !strings.ContainsRune("+-# 0.", rune(c)) && (c < '0' || c > '9') {
+				break
+			}
+		}
+		if j >= len(text) {
+			literal = i
+			break
+		}
+
+		var value []sqlSource
+		if strings.IndexByte(textVerbs, text[j]) >= 0 && argNum < len(args) {
+			value = args[argNum]
+		}
+		if len(value) == 0 {
+			value = []sqlSource{opaqueSource()}
+		}
+		variants = concatSources(variants, value)
+		argNum++
+		literal, i = j+1, j
+	}
+	flush(len(text))
+
+	for i := range variants {
+		variants[i].related = append(variants[i].related, format.related...)
+	}
+	return variants
+}
+
+// parseArgIndex parses the n of an explicit argument index [n].
+func parseArgIndex(s string) (int, bool) {
+	n := 0
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, n > 0
+}
+
+// isErrorType reports whether t is the predeclared error type.
+func isErrorType(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
@@ -0,0 +1,97 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/MirrexOne/unqueryvet/pkg/config"
+)
+
+// goquPackage matches the import paths of every goqu major version
+const goquPackage = "github.com/doug-martin/goqu/..."
+
+// goquProjections are the goqu functions and methods setting the projection
+var goquProjections = []string{selectKeyword, "SelectAppend", "SelectDistinct"}
+
+// goquDialect follows *goqu.SelectDataset values to the methods rendering
+// their SELECT, which selects every column unless a projection was set.
+// ScanStructs and ScanStruct derive the columns from the destination struct.
+// Datasets are immutable, q.Select("id") alone leaves q unchanged.
+var goquDialect = &builderDialect{
+	message:   "goqu_select",
+	typeName:  "SelectDataset",
+	terminals: []string{"ToSQL", "Executor", "ScanVals", "ScanVal", "ScanValsContext", "ScanValContext"},
+	atUse:     true,
+	step: func(_ *analysis.Pass, fn *types.Func, call *ast.CallExpr) builderStep {
+		switch fn.Name() {
+		case selectKeyword, "SelectDistinct":
+			if call != nil && len(call.Args) == 0 {
+				return stepClears
+			}
+			return stepProjects
+		case "ClearSelect":
+			return stepClears
+		}
+		return stepChains
+	},
+}
+
+// analyzeGoquDatasets reports goqu.Star() and goqu.L("*") projections.
+// Datasets rendered without a projection are followed by checkBuilderFlows.
+func analyzeGoquDatasets(pass *analysis.Pass, file *ast.File, cfg *config.UnqueryvetSettings) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || isIgnoredCall(pass, call, cfg) {
+			return true
+		}
+		callee, ok := goquCallee(pass, call)
+		if !ok || !slices.Contains(goquProjections, callee.Name()) {
+			return true
+		}
+		for _, arg := range call.Args {
+			if isGoquStar(pass, arg) {
+				pass.Report(analysis.Diagnostic{
+					Pos:     arg.Pos(),
+					Message: getDetailedWarningMessage("sql_builder"),
+				})
+			}
+		}
+		return true
+	})
+}
+
+// goquCallee returns the goqu function or method invoked by call.
+func goquCallee(pass *analysis.Pass, call *ast.CallExpr) (*types.Func, bool) {
+	if _, ok := call.Fun.(*ast.SelectorExpr); !ok {
+		return nil, false
+	}
+	fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if fn == nil || fn.Pkg() == nil || !matchPackage([]string{goquPackage}, fn.Pkg().Path()) {
+		return nil, false
+	}
+	return fn, true
+}
+
+// isGoquStar reports whether expr is goqu.Star() or a goqu.L("*") literal.
+func isGoquStar(pass *analysis.Pass, expr ast.Expr) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := goquCallee(pass, call)
+	if !ok {
+		return false
+	}
+	switch fn.Name() {
+	case "Star":
+		return true
+	case "L":
+		value, ok := constantString(pass, call.Args[0])
+		return ok && value == "*"
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/MirrexOne/unqueryvet/pkg/config"
+)
+
+const (
+	// gormPackage is the import path of GORM
+	gormPackage = "gorm.io/gorm"
+	// gormDB is the qualified name of the GORM query chain type
+	gormDB = gormPackage + ".DB"
+)
+
+// gormDialect follows *gorm.DB chains to the finders running their SELECT,
+// which selects every column unless a projection was set. Methods called on
+// a database handle start a new chain, methods of a chain change it in
+// place. Scopes may select columns and are trusted. A *gorm.DB parameter is
+// taken for a database handle, so repository functions receiving one are
+// reported at their finders.
+var gormDialect = &builderDialect{
+	message:     "gorm_finder",
+	typeName:    "DB",
+	mutable:     true,
+	clonesRoots: true,
+	atUse:       true,
+	rootParams:  true,
+	terminals:   []string{"Find", "First", "Take", "Last", "FindInBatches", "Scan", "Row", "Rows"},
+	step: func(pass *analysis.Pass, fn *types.Func, call *ast.CallExpr) builderStep {
+		switch fn.Name() {
+		case selectKeyword, "Omit", "Raw", "Exec", "Scopes":
+			return stepProjects
+		case "Distinct":
+			if call == nil || len(call.Args) > 0 {
+				return stepProjects
+			}
+		case "Session":
+			if call == nil || len(call.Args) == 1 && queriesFields(pass, call.Args[0]) {
+				return stepProjects
+			}
+		}
+		return stepChains
+	},
+}
+
+// analyzeGORMChains reports Select("*") calls of *gorm.DB chains. Chains
+// reaching a finder without a projection are followed by checkBuilderFlows.
+func analyzeGORMChains(pass *analysis.Pass, file *ast.File, cfg *config.UnqueryvetSettings) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || isIgnoredCall(pass, call, cfg) {
+			return true
+		}
+		if name, ok := gormMethod(pass, call); ok && name == selectKeyword && len(call.Args) > 0 {
+			if value, ok := constantString(pass, call.Args[0]); ok && value == "*" {
+				pass.Report(analysis.Diagnostic{
+					Pos:     call.Pos(),
+					Message: getDetailedWarningMessage("sql_builder"),
+				})
+			}
+		}
+		return true
+	})
+}
+
+// gormMethod returns the name of the *gorm.DB method invoked by call.
+func gormMethod(pass *analysis.Pass, call *ast.CallExpr) (string, bool) {
+	if _, ok := call.Fun.(*ast.SelectorExpr); !ok {
+		return "", false
+	}
+	fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if fn == nil || !slices.Contains(qualifiedNames(fn), gormDB+"."+fn.Name()) {
+		return "", false
+	}
+	return fn.Name(), true
+}
+
+// queriesFields reports whether expr is a &gorm.Session{...} literal enabling
+// QueryFields, which selects the fields of the model instead of *.
+func queriesFields(pass *analysis.Pass, expr ast.Expr) bool {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "QueryFields" {
+			tv := pass.TypesInfo.Types[kv.Value]
+			return tv.Value != nil && tv.Value.Kind() == constant.Bool && constant.BoolVal(tv.Value)
+		}
+	}
+	return false
+}
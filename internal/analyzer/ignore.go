@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/MirrexOne/unqueryvet/pkg/config"
+)
+
+// isIgnoredCall reports whether call invokes a function or method matched by
+// the ignored-functions setting.
+func isIgnoredCall(pass *analysis.Pass, call *ast.CallExpr, cfg *config.UnqueryvetSettings) bool {
+	if len(cfg.IgnoredFunctions) == 0 {
+		return false
+	}
+	fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	return isIgnoredFunc(fn, cfg)
+}
+
+// isIgnoredFunc reports whether fn is matched by the ignored-functions setting.
+func isIgnoredFunc(fn *types.Func, cfg *config.UnqueryvetSettings) bool {
+	if fn == nil {
+		return false
+	}
+	for _, pattern := range cfg.IgnoredFunctions {
+		for _, name := range qualifiedNames(fn) {
+			if matchGlob(pattern, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// qualifiedNames returns the names fn can be referred to by in settings:
+// its full name, like fmt.Printf or (*database/sql.DB).Query, and for methods
+// also the receiver-agnostic form database/sql.DB.Query.
+func qualifiedNames(fn *types.Func) []string {
+	names := []string{fn.FullName()}
+
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return names
+	}
+	recv := sig.Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	if named, ok := recv.(*types.Named); ok && named.Obj().Pkg() != nil {
+		names = append(names, named.Obj().Pkg().Path()+"."+named.Obj().Name()+"."+fn.Name())
+	}
+	return names
+}
+
+// matchGlob reports whether name matches pattern, where * matches any
+// sequence of characters, including dots and slashes, and ? matches any
+// single character.
+func matchGlob(pattern, name string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := len(name); i >= 0; i-- {
+				if matchGlob(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(name) == 0 {
+				return false
+			}
+		default:
+			if len(name) == 0 || pattern[0] != name[0] {
+				return false
+			}
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/MirrexOne/unqueryvet/internal/sqllex"
+)
+
+// positionalInsert describes an INSERT statement without a target column list.
+type positionalInsert struct {
+	// Offset is the byte offset of the target table name in the query
+	Offset int
+	// Table is the target table as written in the query
+	Table string
+}
+
+// insertModifiers are keywords that may appear between INSERT and the target table.
+var insertModifiers = map[string]bool{
+	"INTO":          true,
+	"IGNORE":        true,
+	"LOW_PRIORITY":  true,
+	"DELAYED":       true,
+	"HIGH_PRIORITY": true,
+	"OR":            true,
+	"REPLACE":       true,
+	"ABORT":         true,
+	"FAIL":          true,
+	"ROLLBACK":      true,
+}
+
+// findPositionalInserts returns INSERT statements in query whose values are
+// matched to columns by position because no target column list is given:
+// INSERT INTO t VALUES (...), INSERT INTO t SELECT ... and DEFAULT VALUES
+// are reported, INSERT INTO t (a, b) ... and MySQL's INSERT ... SET are not.
+func findPositionalInserts(query string) []positionalInsert {
+	tokens := sqllex.Tokenize(query)
+
+	var inserts []positionalInsert
+	for i, tok := range tokens {
+		if !tok.IsKeyword("INSERT") {
+			continue
+		}
+
+		j := i + 1
+		for j < len(tokens) && tokens[j].Kind == sqllex.Ident && insertModifiers[strings.ToUpper(tokens[j].Text)] {
+			j++
+		}
+
+		table, next := parseQualifiedName(tokens, j)
+		if table == "" {
+			continue
+		}
+		if next < len(tokens) && tokens[next].IsKeyword("AS") {
+			next += 2
+		}
+		if next < len(tokens) && tokens[next].IsKeyword("OUTPUT") {
+			next = skipOutputClause(tokens, next+1)
+		}
+
+		if next < len(tokens) && isPositionalInsertSource(tokens, next) {
+			inserts = append(inserts, positionalInsert{Offset: tokens[j].Pos, Table: table})
+		}
+	}
+	return inserts
+}
+
+// isPositionalInsertSource reports whether tokens[i] starts the source of an
+// INSERT statement that has no column list.
+func isPositionalInsertSource(tokens []sqllex.Token, i int) bool {
+	tok := tokens[i]
+	switch {
+	case tok.IsKeyword("VALUES"), tok.IsKeyword("VALUE"), tok.IsKeyword("SELECT"), tok.IsKeyword("WITH"), tok.IsKeyword("TABLE"):
+		return true
+	case tok.IsKeyword("DEFAULT"):
+		return i+1 < len(tokens) && tokens[i+1].IsKeyword("VALUES")
+	case tok.IsOperator("("):
+		// A parenthesized query rather than a column list
+		return i+1 < len(tokens) && (tokens[i+1].IsKeyword("SELECT") || tokens[i+1].IsKeyword("WITH"))
+	default:
+		return false
+	}
+}
+
+// parseQualifiedName parses a possibly qualified name like t, s.t or "s"."t"
+// starting at tokens[i]. It returns the name as written and the index of the
+// first token after it, or an empty name if tokens[i] is not an identifier.
+func parseQualifiedName(tokens []sqllex.Token, i int) (string, int) {
+	var name strings.Builder
+	for i < len(tokens) && (tokens[i].Kind == sqllex.Ident || tokens[i].Kind == sqllex.QuotedIdent) {
+		name.WriteString(tokens[i].Text)
+		if i+2 >= len(tokens) || !tokens[i+1].IsOperator(".") {
+			return name.String(), i + 1
+		}
+		name.WriteByte('.')
+		i += 2
+	}
+	return "", i
+}
+
+// skipOutputClause returns the index of the first token after the item list
+// of an OUTPUT clause that starts at tokens[i].
+func skipOutputClause(tokens []sqllex.Token, i int) int {
+	depth := 0
+	for ; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch {
+		case tok.IsOperator("("):
+			depth++
+		case tok.IsOperator(")"):
+			depth--
+		case depth == 0 && (tok.IsKeyword("VALUES") || tok.IsKeyword("SELECT") || tok.IsKeyword("DEFAULT")):
+			return i
+		}
+	}
+	return i
+}
@@ -0,0 +1,60 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/MirrexOne/unqueryvet/pkg/config"
+)
+
+// jetPackage matches the import paths of go-jet and its dialect packages
+const jetPackage = "github.com/go-jet/jet/..."
+
+// analyzeJetStatements reports the STAR projection passed to go-jet SELECT
+// functions and methods, like postgres.SELECT(postgres.STAR) or
+// Users.SELECT(STAR). COUNT(STAR) selects no columns and is not reported.
+func analyzeJetStatements(pass *analysis.Pass, file *ast.File, cfg *config.UnqueryvetSettings) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || isIgnoredCall(pass, call, cfg) {
+			return true
+		}
+		fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if fn == nil || fn.Name() != "SELECT" || !isJetObject(fn) {
+			return true
+		}
+		for _, arg := range call.Args {
+			if isJetStar(pass, arg) {
+				pass.Report(analysis.Diagnostic{
+					Pos:     arg.Pos(),
+					Message: getDetailedWarningMessage("sql_builder"),
+				})
+			}
+		}
+		return true
+	})
+}
+
+// isJetStar reports whether expr refers to the STAR variable of a go-jet
+// package, qualified or dot-imported.
+func isJetStar(pass *analysis.Pass, expr ast.Expr) bool {
+	var id *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	default:
+		return false
+	}
+	v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+	return ok && v.Name() == "STAR" && !v.IsField() && isJetObject(v)
+}
+
+// isJetObject reports whether obj is declared in a go-jet package.
+func isJetObject(obj types.Object) bool {
+	return obj.Pkg() != nil && matchPackage([]string{jetPackage}, obj.Pkg().Path())
+}
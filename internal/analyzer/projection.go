@@ -0,0 +1,388 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/MirrexOne/unqueryvet/internal/sqllex"
+)
+
+// Star contexts tell where in a query the SELECT owning a star appears.
+// They double as keys for getDetailedWarningMessage.
+const (
+	// contextTopLevel is the outermost SELECT of a statement
+	contextTopLevel = "top_level"
+	// contextSubquery is a scalar subquery or a subquery in IN, ANY, ALL and comparisons
+	contextSubquery = "nested"
+	// contextCTE is the body of a common table expression: WITH x AS (SELECT ...)
+	contextCTE = "cte"
+	// contextDerivedTable is a subquery in FROM or JOIN: FROM (SELECT ...) t
+	contextDerivedTable = "derived_table"
+	// contextUnion is a SELECT following UNION, INTERSECT or EXCEPT
+	contextUnion = "union"
+	// contextExists is the subquery of EXISTS or NOT EXISTS, whose projection is never materialized
+	contextExists = "exists"
+	// contextReturning is the RETURNING clause of INSERT, UPDATE or DELETE
+	contextReturning = "returning"
+	// contextOutput is the SQL Server OUTPUT clause: OUTPUT inserted.*
+	contextOutput = "output"
+	// contextInsertSelect is the SELECT feeding an INSERT: INSERT INTO t SELECT * FROM s
+	contextInsertSelect = "insert_select"
+)
+
+// selectStar describes a star projection found in a query.
+type selectStar struct {
+	// Offset is the byte offset of the "*" token in the query
+	Offset int
+	// Qualifier is the table or schema-qualified table of a qualified star
+	// like u.* or "schema"."table".*, as written in the query. It is empty
+	// for a bare star.
+	Qualifier string
+	// Context is one of the context* constants
+	Context string
+	// Start is the byte offset of the first token of the star item, which
+	// is the qualifier of a qualified star
+	Start int
+	// Sole is set when the star is the only item of its projection list
+	Sole bool
+	// From is the FROM clause of the SELECT owning the star, or nil when it
+	// has none or the star is not in a SELECT projection
+	From *fromClause
+}
+
+// selectModifiers are keywords that may appear between SELECT and the projection list.
+var selectModifiers = map[string]bool{
+	"ALL":                 true,
+	"DISTINCT":            true,
+	"DISTINCTROW":         true,
+	"HIGH_PRIORITY":       true,
+	"STRAIGHT_JOIN":       true,
+	"SQL_SMALL_RESULT":    true,
+	"SQL_BIG_RESULT":      true,
+	"SQL_BUFFER_RESULT":   true,
+	"SQL_NO_CACHE":        true,
+	"SQL_CALC_FOUND_ROWS": true,
+}
+
+// projectionTerminators are keywords that end a projection list. SELECT,
+// VALUES and DEFAULT end the OUTPUT clause of SQL Server INSERT statements.
+var projectionTerminators = map[string]bool{
+	"FROM":      true,
+	"INTO":      true,
+	"WHERE":     true,
+	"GROUP":     true,
+	"HAVING":    true,
+	"WINDOW":    true,
+	"ORDER":     true,
+	"LIMIT":     true,
+	"OFFSET":    true,
+	"FETCH":     true,
+	"FOR":       true,
+	"UNION":     true,
+	"INTERSECT": true,
+	"EXCEPT":    true,
+	"MINUS":     true,
+	"SELECT":    true,
+	"VALUES":    true,
+	"DEFAULT":   true,
+}
+
+// findSelectStars tokenizes query and returns every star that forms a whole
+// item of a SELECT projection list or of the RETURNING and OUTPUT clauses of
+// data-modifying statements, either bare or qualified. Stars inside function
+// calls such as COUNT(*), in arithmetic, in string literals or in comments
+// are not reported.
+func findSelectStars(query string) []selectStar {
+	tokens := sqllex.Tokenize(query)
+
+	var stars []selectStar
+	for i, tok := range tokens {
+		var (
+			context string
+			start   = i + 1
+		)
+		switch {
+		case tok.IsKeyword("SELECT"):
+			context = selectContext(tokens, i)
+			start = skipSelectModifiers(tokens, start)
+		case tok.IsKeyword("RETURNING") && isDataModifying(tokens, i):
+			context = contextReturning
+		case tok.IsKeyword("OUTPUT") && isDataModifying(tokens, i):
+			context = contextOutput
+		default:
+			continue
+		}
+
+		items := projectionItems(tokens, start)
+		var from *fromClause
+		if tok.IsKeyword("SELECT") {
+			from = parseFrom(tokens, projectionEnd(start, items))
+		}
+		for _, item := range items {
+			if star, ok := parseStarItem(item); ok {
+				star.Context, star.From, star.Sole = context, from, len(items) == 1
+				stars = append(stars, star)
+			}
+		}
+	}
+	return stars
+}
+
+// selectContext classifies the SELECT keyword at tokens[i] by the tokens preceding it.
+func selectContext(tokens []sqllex.Token, i int) string {
+	j := i - 1
+	if j < 0 {
+		return contextTopLevel
+	}
+	if isSetOperator(tokens, j) {
+		return contextUnion
+	}
+	if !tokens[j].IsOperator("(") {
+		if precededByKeyword(tokens, j, "INSERT") {
+			return contextInsertSelect
+		}
+		return contextTopLevel
+	}
+
+	// Look through redundant parentheses: ((SELECT ...))
+	for j > 0 && tokens[j-1].IsOperator("(") {
+		j--
+	}
+	if j == 0 {
+		return contextTopLevel
+	}
+
+	prev := tokens[j-1]
+	switch {
+	case isSetOperator(tokens, j-1):
+		return contextUnion
+	case prev.IsKeyword("EXISTS"):
+		return contextExists
+	case prev.IsKeyword("FROM"), prev.IsKeyword("JOIN"), prev.IsKeyword("LATERAL"), prev.IsKeyword("APPLY"):
+		return contextDerivedTable
+	case prev.IsOperator(",") && enclosingClause(tokens, j-1) == "FROM":
+		return contextDerivedTable
+	case prev.IsKeyword("AS"), prev.IsKeyword("MATERIALIZED"):
+		if hasWithClause(tokens, j-1) {
+			return contextCTE
+		}
+		// CREATE TABLE ... AS (SELECT ...) and CREATE VIEW ... AS (SELECT ...)
+		return contextTopLevel
+	default:
+		return contextSubquery
+	}
+}
+
+// isSetOperator reports whether tokens[j] ends a set operator: UNION, UNION ALL, EXCEPT DISTINCT, ...
+func isSetOperator(tokens []sqllex.Token, j int) bool {
+	if j > 0 && (tokens[j].IsKeyword("ALL") || tokens[j].IsKeyword("DISTINCT")) {
+		j--
+	}
+	tok := tokens[j]
+	return tok.IsKeyword("UNION") || tok.IsKeyword("INTERSECT") || tok.IsKeyword("EXCEPT") || tok.IsKeyword("MINUS")
+}
+
+// clauseKeywords are keywords that start a clause of a statement.
+var clauseKeywords = map[string]bool{
+	"SELECT": true,
+	"FROM":   true,
+	"WHERE":  true,
+	"GROUP":  true,
+	"HAVING": true,
+	"ORDER":  true,
+	"SET":    true,
+	"VALUES": true,
+	"ON":     true,
+	"USING":  true,
+}
+
+// enclosingClause returns the upper-cased clause keyword that tokens[j] belongs to,
+// looking backwards at the same parenthesis depth.
+func enclosingClause(tokens []sqllex.Token, j int) string {
+	depth := 0
+	for ; j >= 0; j-- {
+		tok := tokens[j]
+		switch {
+		case tok.IsOperator(")"):
+			depth++
+		case tok.IsOperator("("):
+			depth--
+			if depth < 0 {
+				return ""
+			}
+		case depth == 0 && tok.Kind == sqllex.Ident && clauseKeywords[strings.ToUpper(tok.Text)]:
+			return strings.ToUpper(tok.Text)
+		}
+	}
+	return ""
+}
+
+// hasWithClause reports whether a WITH keyword precedes tokens[j] at the same parenthesis depth.
+func hasWithClause(tokens []sqllex.Token, j int) bool {
+	return precededByKeyword(tokens, j, "WITH")
+}
+
+// isDataModifying reports whether tokens[j] belongs to an INSERT, UPDATE, DELETE or MERGE statement.
+func isDataModifying(tokens []sqllex.Token, j int) bool {
+	return precededByKeyword(tokens, j, "INSERT", "UPDATE", "DELETE", "MERGE")
+}
+
+// precededByKeyword reports whether one of keywords precedes tokens[j] at the
+// same parenthesis depth within the same statement.
+func precededByKeyword(tokens []sqllex.Token, j int, keywords ...string) bool {
+	depth := 0
+	for ; j >= 0; j-- {
+		tok := tokens[j]
+		switch {
+		case tok.IsOperator(";"):
+			return false
+		case tok.IsOperator(")"):
+			depth++
+		case tok.IsOperator("("):
+			depth--
+			if depth < 0 {
+				return false
+			}
+		case depth == 0 && tok.Kind == sqllex.Ident:
+			for _, kw := range keywords {
+				if tok.IsKeyword(kw) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// skipSelectModifiers returns the index of the first projection token after
+// SELECT modifiers like DISTINCT, DISTINCT ON (...) and TOP n.
+func skipSelectModifiers(tokens []sqllex.Token, i int) int {
+	for i < len(tokens) {
+		tok := tokens[i]
+		switch {
+		case tok.IsKeyword("DISTINCT") && i+1 < len(tokens) && tokens[i+1].IsKeyword("ON"):
+			i = skipParens(tokens, i+2)
+		case tok.IsKeyword("TOP"):
+			i = skipParens(tokens, i+1)
+			if i < len(tokens) && tokens[i].Kind == sqllex.Number {
+				i++
+			}
+			if i < len(tokens) && tokens[i].IsKeyword("PERCENT") {
+				i++
+			}
+			if i+1 < len(tokens) && tokens[i].IsKeyword("WITH") && tokens[i+1].IsKeyword("TIES") {
+				i += 2
+			}
+		case tok.Kind == sqllex.Ident && selectModifiers[strings.ToUpper(tok.Text)]:
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// skipParens skips a parenthesized group starting at i, if there is one.
+func skipParens(tokens []sqllex.Token, i int) int {
+	if i >= len(tokens) || !tokens[i].IsOperator("(") {
+		return i
+	}
+	depth := 0
+	for ; i < len(tokens); i++ {
+		switch {
+		case tokens[i].IsOperator("("):
+			depth++
+		case tokens[i].IsOperator(")"):
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return i
+}
+
+// projectionItems splits the projection list starting at tokens[i] into its
+// comma-separated items. The list ends at a clause keyword, at a closing
+// parenthesis of the enclosing group, at a semicolon or at the end of input.
+// An item followed by anything else is not a valid projection and the list
+// is discarded, which keeps prose like "select * items" from matching.
+func projectionItems(tokens []sqllex.Token, i int) [][]sqllex.Token {
+	var (
+		items [][]sqllex.Token
+		start = i
+		depth = 0
+	)
+
+	for ; i < len(tokens); i++ {
+		tok := tokens[i]
+		if depth == 0 && endsProjection(tok) {
+			break
+		}
+		if depth == 0 && tok.Kind == sqllex.Opaque && isStarItem(tokens[start:i]) {
+			// Unknown text after a star most likely continues with FROM
+			break
+		}
+
+		switch {
+		case depth == 0 && tok.IsOperator(","):
+			items = append(items, tokens[start:i])
+			start = i + 1
+		case depth == 0 && isStarItem(tokens[start:i]):
+			// Nothing may follow a star inside a projection item
+			return nil
+		case tok.IsOperator("("):
+			depth++
+		case tok.IsOperator(")"):
+			depth--
+		}
+	}
+
+	return append(items, tokens[start:i])
+}
+
+// projectionEnd returns the index of the token following the projection
+// list of items that starts at tokens[start].
+func projectionEnd(start int, items [][]sqllex.Token) int {
+	end := start + len(items) - 1
+	for _, item := range items {
+		end += len(item)
+	}
+	return end
+}
+
+// endsProjection reports whether tok terminates a projection list when it
+// appears outside of any parentheses opened by the list itself.
+func endsProjection(tok sqllex.Token) bool {
+	if tok.IsOperator(")") || tok.IsOperator(";") {
+		return true
+	}
+	return tok.Kind == sqllex.Ident && projectionTerminators[strings.ToUpper(tok.Text)]
+}
+
+// isStarItem reports whether item consists of a bare or qualified star.
+func isStarItem(item []sqllex.Token) bool {
+	_, ok := parseStarItem(item)
+	return ok
+}
+
+// parseStarItem parses a projection item of the form *, t.* or s.t.* where
+// each name may be a bare or quoted identifier.
+func parseStarItem(item []sqllex.Token) (selectStar, bool) {
+	if len(item) == 0 || len(item)%2 == 0 || !item[len(item)-1].IsOperator("*") {
+		return selectStar{}, false
+	}
+
+	var qualifier strings.Builder
+	for i := 0; i < len(item)-1; i += 2 {
+		name, dot := item[i], item[i+1]
+		if name.Kind != sqllex.Ident && name.Kind != sqllex.QuotedIdent || !dot.IsOperator(".") {
+			return selectStar{}, false
+		}
+		if i > 0 {
+			qualifier.WriteByte('.')
+		}
+		qualifier.WriteString(name.Text)
+	}
+
+	return selectStar{Offset: item[len(item)-1].Pos, Qualifier: qualifier.String(), Start: item[0].Pos}, true
+}
@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"bytes"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// diagnosticCollector merges diagnostics reported more than once, e.g. for a
+// constant query used by several calls, into a single diagnostic carrying
+// the related information of every report. Diagnostics are the same when
+// they share position, message and category; different findings at the same
+// position, like a SELECT * and the positional Scan of its rows, are kept
+// apart. Suggested fixes are kept only when every report agrees on them,
+// since a literal used in several queries may stand for different columns in
+// each.
+type diagnosticCollector struct {
+	diagnostics []analysis.Diagnostic
+	index       map[diagnosticKey]int
+}
+
+// diagnosticKey identifies the reports of the same finding.
+type diagnosticKey struct {
+	pos      token.Pos
+	message  string
+	category string
+}
+
+func keyOf(d analysis.Diagnostic) diagnosticKey {
+	return diagnosticKey{pos: d.Pos, message: d.Message, category: d.Category}
+}
+
+// collectDiagnostics returns a copy of pass whose Report method feeds the
+// returned collector instead of the driver.
+func collectDiagnostics(pass *analysis.Pass) (*analysis.Pass, *diagnosticCollector) {
+	collector := &diagnosticCollector{index: make(map[diagnosticKey]int)}
+	collecting := *pass
+	collecting.Report = collector.report
+	return &collecting, collector
+}
+
+// report records d, merging its related information into an earlier
+// report of the same finding.
+func (c *diagnosticCollector) report(d analysis.Diagnostic) {
+	key := keyOf(d)
+	idx, seen := c.index[key]
+	if !seen {
+		c.index[key] = len(c.diagnostics)
+		c.diagnostics = append(c.diagnostics, d)
+		return
+	}
+
+	existing := &c.diagnostics[idx]
+	if !sameFixes(existing.SuggestedFixes, d.SuggestedFixes) {
+		existing.SuggestedFixes = nil
+	}
+	for _, related := range d.Related {
+		if !hasRelated(existing.Related, related) {
+			existing.Related = append(existing.Related, related)
+		}
+	}
+}
+
+// flush passes the collected diagnostics to report in the order they were first seen.
+func (c *diagnosticCollector) flush(report func(analysis.Diagnostic)) {
+	for _, d := range c.diagnostics {
+		report(d)
+	}
+	c.diagnostics, c.index = nil, make(map[diagnosticKey]int)
+}
+
+func hasRelated(list []analysis.RelatedInformation, info analysis.RelatedInformation) bool {
+	for _, existing := range list {
+		if existing.Pos == info.Pos && existing.Message == info.Message {
+			return true
+		}
+	}
+	return false
+}
+
+// sameFixes reports whether a and b make the same edits.
+func sameFixes(a, b []analysis.SuggestedFix) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i].TextEdits) != len(b[i].TextEdits) {
+			return false
+		}
+		for j, edit := range a[i].TextEdits {
+			other := b[i].TextEdits[j]
+			if edit.Pos != other.Pos || edit.End != other.End || !bytes.Equal(edit.NewText, other.NewText) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,337 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// columnMapper maps the fields of a scan destination struct to columns the
+// way a database library does.
+type columnMapper struct {
+	// column returns the column of a field with the given tag, or false
+	// when the field is not mapped to a column
+	column func(tag reflect.StructTag, field string) (string, bool)
+}
+
+// columnMappers lists the column mappers of the libraries scanning rows
+// into structs, by import path pattern.
+var columnMappers = []struct {
+	pattern string
+	mapper  columnMapper
+}{
+	{"github.com/jmoiron/sqlx", columnMapper{dbTagColumn(strings.ToLower)}},
+	{"github.com/georgysavva/scany/...", columnMapper{dbTagColumn(snakeCase)}},
+	{"gorm.io/gorm", columnMapper{gormTagColumn}},
+	{"github.com/uptrace/bun", columnMapper{bunTagColumn}},
+}
+
+// scanFuncs lists the functions and methods that run the query passed to
+// them and scan the rows into the argument preceding the query, in the
+// format of the ignored-functions setting.
+var scanFuncs = []string{
+	"github.com/jmoiron/sqlx.*.Select", "github.com/jmoiron/sqlx.*.SelectContext",
+	"github.com/jmoiron/sqlx.*.Get", "github.com/jmoiron/sqlx.*.GetContext",
+	"github.com/jmoiron/sqlx.Select", "github.com/jmoiron/sqlx.SelectContext",
+	"github.com/jmoiron/sqlx.Get", "github.com/jmoiron/sqlx.GetContext",
+	"github.com/georgysavva/scany/*.Select", "github.com/georgysavva/scany/*.Get",
+}
+
+// scanMethods lists the methods that scan the rows of the query run by
+// their receiver, like db.Raw(query).Scan(&users), with the index of the
+// destination argument.
+var scanMethods = map[string]int{
+	"gorm.io/gorm.DB.Scan":                   0,
+	"gorm.io/gorm.DB.Find":                   0,
+	"gorm.io/gorm.DB.First":                  0,
+	"gorm.io/gorm.DB.Take":                   0,
+	"gorm.io/gorm.DB.Last":                   0,
+	"github.com/uptrace/bun.RawQuery.Scan":   1,
+	"github.com/jmoiron/sqlx.Row.StructScan": 0,
+}
+
+// scanColumns returns the columns that the rows of the query passed to call
+// are scanned into, taken from the fields of the destination struct and
+// their tags, with the name of the struct. It returns false when call does
+// not scan into a struct.
+func scanColumns(pass *analysis.Pass, call *ast.CallExpr) ([]string, string, bool) {
+	dest, fn, ok := scanDestination(pass, call)
+	if !ok {
+		return nil, "", false
+	}
+	var mapper *columnMapper
+	for i := range columnMappers {
+		if matchPackage([]string{columnMappers[i].pattern}, fn.Pkg().Path()) {
+			mapper = &columnMappers[i].mapper
+			break
+		}
+	}
+	if mapper == nil {
+		return nil, "", false
+	}
+
+	elem, ok := destinationStruct(pass.TypesInfo.TypeOf(dest))
+	if !ok {
+		return nil, "", false
+	}
+	columns, ok := structColumns(elem.Underlying().(*types.Struct), mapper, 0)
+	if !ok || len(columns) == 0 {
+		return nil, "", false
+	}
+	name := "the destination struct"
+	if _, named := types.Unalias(elem).(*types.Named); named {
+		name = types.TypeString(elem, types.RelativeTo(pass.Pkg))
+	}
+	return columns, name, true
+}
+
+// scanDestination returns the destination argument of a call running a
+// query and scanning its rows, either directly or through a scan method
+// called on its result, together with the function doing the scan.
+func scanDestination(pass *analysis.Pass, call *ast.CallExpr) (ast.Expr, *types.Func, bool) {
+	if fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func); fn != nil && matchesAny(scanFuncs, fn) {
+		// The destination precedes the query, the first string parameter
+		sig := fn.Type().(*types.Signature)
+		for i := 1; i < sig.Params().Len() && i < len(call.Args); i++ {
+			if isStringType(sig.Params().At(i).Type()) {
+				return call.Args[i-1], fn, true
+			}
+		}
+		return nil, nil, false
+	}
+
+	file := fileOf(pass, call)
+	if file == nil {
+		return nil, nil, false
+	}
+	path, _ := astutil.PathEnclosingInterval(file, call.Pos(), call.End())
+	if len(path) < 3 {
+		return nil, nil, false
+	}
+	sel, ok := path[1].(*ast.SelectorExpr)
+	if !ok || sel.X != call {
+		return nil, nil, false
+	}
+	scan, ok := path[2].(*ast.CallExpr)
+	if !ok || scan.Fun != sel {
+		return nil, nil, false
+	}
+	fn, _ := typeutil.Callee(pass.TypesInfo, scan).(*types.Func)
+	if fn == nil {
+		return nil, nil, false
+	}
+	for _, name := range qualifiedNames(fn) {
+		if i, ok := scanMethods[name]; ok && i < len(scan.Args) {
+			return scan.Args[i], fn, true
+		}
+	}
+	return nil, nil, false
+}
+
+// matchesAny reports whether fn matches one of patterns.
+func matchesAny(patterns []string, fn *types.Func) bool {
+	for _, name := range qualifiedNames(fn) {
+		for _, pattern := range patterns {
+			if matchGlob(pattern, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fileOf returns the file of the analyzed package containing node.
+func fileOf(pass *analysis.Pass, node ast.Node) *ast.File {
+	for _, file := range pass.Files {
+		if file.FileStart <= node.Pos() && node.Pos() < file.FileEnd {
+			return file
+		}
+	}
+	return nil
+}
+
+// destinationStruct returns the struct type T rows are scanned into for a
+// destination of type *T, *[]T or *[]*T.
+func destinationStruct(t types.Type) (types.Type, bool) {
+	ptr, ok := types.Unalias(t).(*types.Pointer)
+	if !ok {
+		return nil, false
+	}
+	elem := ptr.Elem()
+	if slice, ok := elem.Underlying().(*types.Slice); ok {
+		elem = slice.Elem()
+		if p, ok := elem.Underlying().(*types.Pointer); ok {
+			elem = p.Elem()
+		}
+	}
+	if isScalarField(elem) {
+		return nil, false
+	}
+	_, ok = elem.Underlying().(*types.Struct)
+	return elem, ok
+}
+
+// maxEmbedding bounds the depth of embedded structs that are flattened.
+const maxEmbedding = 8
+
+// structColumns returns the columns of the fields of st in declaration
+// order, flattening embedded structs. It fails on fields that libraries map
+// to several columns or to related tables, like nested structs and slices.
+func structColumns(st *types.Struct, mapper *columnMapper, depth int) ([]string, bool) {
+	if depth > maxEmbedding {
+		return nil, false
+	}
+	var columns []string
+	for i := 0; i < st.NumFields(); i++ {
+		field, tag := st.Field(i), reflect.StructTag(st.Tag(i))
+		if !field.Exported() && !field.Embedded() {
+			continue
+		}
+		column, mapped := mapper.column(tag, field.Name())
+		if !mapped {
+			continue
+		}
+
+		t := field.Type()
+		if p, ok := t.Underlying().(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		if field.Embedded() && !hasColumnName(tag) {
+			embedded, ok := t.Underlying().(*types.Struct)
+			if !ok || isScalarField(t) {
+				return nil, false
+			}
+			nested, ok := structColumns(embedded, mapper, depth+1)
+			if !ok {
+				return nil, false
+			}
+			columns = append(columns, nested...)
+			continue
+		}
+		if !field.Exported() || !isScalarField(t) {
+			return nil, false
+		}
+		columns = append(columns, column)
+	}
+	return columns, true
+}
+
+// hasColumnName reports whether tag names the column of its field.
+func hasColumnName(tag reflect.StructTag) bool {
+	if name, _, _ := strings.Cut(tag.Get("db"), ","); name != "" {
+		return true
+	}
+	if name, _, _ := strings.Cut(tag.Get("bun"), ","); name != "" && !strings.Contains(name, ":") {
+		return true
+	}
+	_, ok := gormSetting(tag, "COLUMN")
+	return ok
+}
+
+// isScalarField reports whether values of type t are scanned from a single
+// column: basic types, byte slices, time.Time and sql.Scanner implementations.
+func isScalarField(t types.Type) bool {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return true
+	case *types.Slice:
+		if b, ok := u.Elem().Underlying().(*types.Basic); ok && b.Kind() == types.Byte {
+			return true
+		}
+	}
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		obj := named.Obj()
+		if obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time" {
+			return true
+		}
+	}
+	scan, _, _ := types.LookupFieldOrMethod(types.NewPointer(t), true, nil, "Scan")
+	_, isMethod := scan.(*types.Func)
+	return isMethod
+}
+
+// dbTagColumn returns the mapping of the db tag used by sqlx and scany,
+// naming untagged fields with name.
+func dbTagColumn(name func(string) string) func(reflect.StructTag, string) (string, bool) {
+	return func(tag reflect.StructTag, field string) (string, bool) {
+		column, _, _ := strings.Cut(tag.Get("db"), ",")
+		switch column {
+		case "-":
+			return "", false
+		case "":
+			return name(field), true
+		}
+		return column, true
+	}
+}
+
+// gormTagColumn maps fields like GORM: gorm:"column:name" names the column,
+// gorm:"-" ignores the field and other fields are named in snake case.
+func gormTagColumn(tag reflect.StructTag, field string) (string, bool) {
+	value := tag.Get("gorm")
+	if value == "-" || strings.HasPrefix(value, "-:") {
+		return "", false
+	}
+	if column, ok := gormSetting(tag, "COLUMN"); ok {
+		return column, true
+	}
+	return snakeCase(field), true
+}
+
+// gormSetting returns the value of a key:value setting of a gorm tag.
+func gormSetting(tag reflect.StructTag, key string) (string, bool) {
+	for _, setting := range strings.Split(tag.Get("gorm"), ";") {
+		name, value, _ := strings.Cut(setting, ":")
+		if strings.EqualFold(strings.TrimSpace(name), key) {
+			return strings.TrimSpace(value), true
+		}
+	}
+	return "", false
+}
+
+// bunTagColumn maps fields like bun: the first part of the bun tag names the
+// column, bun:"-" ignores the field, relations and the table name carried by
+// bun.BaseModel are not columns, and other fields are named in snake case.
+func bunTagColumn(tag reflect.StructTag, field string) (string, bool) {
+	value, ok := tag.Lookup("bun")
+	if !ok {
+		return snakeCase(field), true
+	}
+	name, options, _ := strings.Cut(value, ",")
+	if name == "-" || strings.Contains(name, ":") {
+		return "", false
+	}
+	for _, option := range strings.Split(options, ",") {
+		if strings.HasPrefix(option, "rel:") || strings.HasPrefix(option, "m2m:") {
+			return "", false
+		}
+	}
+	if name == "" {
+		return snakeCase(field), true
+	}
+	return name, true
+}
+
+// snakeCase names a field in snake case like GORM and bun, keeping
+// initialisms together: UserID becomes user_id and HTTPStatus http_status.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower && unicode.IsUpper(runes[i-1]) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,239 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/MirrexOne/unqueryvet/internal/sqllex"
+	"github.com/MirrexOne/unqueryvet/pkg/config"
+	"github.com/MirrexOne/unqueryvet/pkg/schema"
+)
+
+// positionalScanCategory is the category of diagnostics for SELECT * rows
+// scanned by position, which break at run time rather than only wasting
+// resources, so that drivers can give them a higher severity.
+const positionalScanCategory = "positional-scan"
+
+// positionalScans lists the methods scanning the columns of a row into their
+// arguments by position, in the format of the ignored-functions setting.
+var positionalScans = []string{
+	"database/sql.Rows.Scan",
+	"database/sql.Row.Scan",
+	"github.com/jackc/pgx/*.Rows.Scan",
+	"github.com/jackc/pgx/*.Row.Scan",
+}
+
+// checkScanFlows reports the positional Scan calls of rows returned by a
+// query with a top-level SELECT *, like
+//
+//	rows, _ := db.Query("SELECT * FROM users")
+//	rows.Scan(&id, &name)
+//
+// The rows are followed back from the receiver of Scan to the call running
+// the query in the SSA form of each function. With a schema catalog, the
+// number of destinations is also compared with the number of columns.
+func checkScanFlows(pass *analysis.Pass, funcs []*ssa.Function, files []*ast.File, cfg *config.UnqueryvetSettings) {
+	calls := callsByLparen(files)
+
+	for _, fn := range funcs {
+		if !inFiles(files, fn.Pos()) {
+			continue
+		}
+		var eval *flowEvaluator
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				site, ok := instr.(ssa.CallInstruction)
+				if !ok {
+					continue
+				}
+				recv, ok := positionalScanReceiver(site.Common())
+				if !ok {
+					continue
+				}
+				scan := calls[site.Common().Pos()]
+				query := rowsQuery(recv, 0)
+				if scan == nil || query == nil {
+					continue
+				}
+				if eval == nil {
+					eval = newFlowEvaluator(pass, cfg, fn, calls)
+				}
+				for _, src := range queryArgSources(pass, eval, query, calls, cfg) {
+					if checkScannedQuery(pass, src, scan, cfg) {
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// positionalScanReceiver returns the rows a call scans by position.
+func positionalScanReceiver(common *ssa.CallCommon) (ssa.Value, bool) {
+	if common.IsInvoke() {
+		if matchesAny(positionalScans, common.Method) {
+			return common.Value, true
+		}
+		return nil, false
+	}
+	callee := common.StaticCallee()
+	if callee == nil || len(common.Args) == 0 {
+		return nil, false
+	}
+	fn, ok := callee.Object().(*types.Func)
+	if !ok || fn.Type().(*types.Signature).Recv() == nil || !matchesAny(positionalScans, fn) {
+		return nil, false
+	}
+	return common.Args[0], true
+}
+
+// rowsQuery returns the call that produced rows, following the extraction
+// of results, interface conversions and local variables stored once.
+func rowsQuery(v ssa.Value, depth int) *ssa.Call {
+	if depth > maxFlowDepth {
+		return nil
+	}
+	switch v := v.(type) {
+	case *ssa.Call:
+		return v
+	case *ssa.Extract:
+		if v.Index == 0 {
+			return rowsQuery(v.Tuple, depth+1)
+		}
+	case *ssa.MakeInterface:
+		return rowsQuery(v.X, depth+1)
+	case *ssa.ChangeInterface:
+		return rowsQuery(v.X, depth+1)
+	case *ssa.UnOp:
+		// Variables captured by closures live in memory
+		if alloc, ok := v.X.(*ssa.Alloc); ok && v.Op == token.MUL {
+			var stored ssa.Value
+			for _, ref := range *alloc.Referrers() {
+				if store, ok := ref.(*ssa.Store); ok && store.Addr == alloc {
+					if stored != nil {
+						return nil
+					}
+					stored = store.Val
+				}
+			}
+			if stored != nil {
+				return rowsQuery(stored, depth+1)
+			}
+		}
+	}
+	return nil
+}
+
+// queryArgSources returns the possible texts of the first string argument of
+// the call running a query.
+func queryArgSources(pass *analysis.Pass, eval *flowEvaluator, query *ssa.Call, calls map[token.Pos]*ast.CallExpr, cfg *config.UnqueryvetSettings) []sqlSource {
+	call := calls[query.Pos()]
+	if call == nil || !isCheckedCall(pass, call, cfg) {
+		return nil
+	}
+	exprs := argumentExprs(&query.Call, call)
+	for i, arg := range query.Call.Args {
+		if !isStringType(arg.Type()) {
+			continue
+		}
+		if exprs[i] != nil {
+			if src, ok := constantSource(pass, exprs[i]); ok {
+				return []sqlSource{src}
+			}
+		}
+		return eval.eval(arg, exprs[i], 0)
+	}
+	return nil
+}
+
+// checkScannedQuery reports scan when the rows of query come from a
+// top-level SELECT *, and reports whether it did.
+func checkScannedQuery(pass *analysis.Pass, src sqlSource, scan *ast.CallExpr, cfg *config.UnqueryvetSettings) bool {
+	var star *selectStar
+	for _, s := range selectStarsInQuery(src.text, cfg) {
+		if s.Context == contextTopLevel {
+			star = &s
+			break
+		}
+	}
+	if star == nil {
+		return false
+	}
+
+	message := "SELECT * rows scanned by position - Scan breaks or misassigns values when columns are added or reordered, list the columns in the query"
+	if cfg.Catalog != nil && !scan.Ellipsis.IsValid() {
+		if count, tables, ok := resultColumns(src.text, cfg.Catalog); ok && count != len(scan.Args) {
+			message = fmt.Sprintf("Scan has %s but SELECT * returns %s of %s - list the columns in the query",
+				countOf(len(scan.Args), "destination"), countOf(count, "column"), strings.Join(tables, ", "))
+		}
+	}
+
+	var related []analysis.RelatedInformation
+	if pos := src.posAt(star.Offset); pos.IsValid() && (pos < scan.Pos() || pos >= scan.End()) {
+		related = append(related, analysis.RelatedInformation{Pos: pos, Message: "SELECT * here"})
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:      scan.Pos(),
+		End:      scan.End(),
+		Category: positionalScanCategory,
+		Message:  message,
+		Related:  related,
+	})
+	return true
+}
+
+// resultColumns returns the number of columns returned by the outermost
+// SELECT of query, expanding its stars with the catalog, together with the
+// tables the stars stand for. It fails when a star cannot be expanded or an
+// item is only known at run time.
+func resultColumns(query string, catalog *schema.Catalog) (int, []string, bool) {
+	tokens := sqllex.Tokenize(query)
+	for i, tok := range tokens {
+		if !tok.IsKeyword("SELECT") || selectContext(tokens, i) != contextTopLevel {
+			continue
+		}
+		start := skipSelectModifiers(tokens, i+1)
+		items := projectionItems(tokens, start)
+		from := parseFrom(tokens, projectionEnd(start, items))
+
+		var (
+			count  int
+			tables []string
+		)
+		for _, item := range items {
+			star, ok := parseStarItem(item)
+			if !ok {
+				for _, tok := range item {
+					if tok.Kind == sqllex.Opaque {
+						return 0, nil, false
+					}
+				}
+				count++
+				continue
+			}
+			star.From = from
+			columns, names, ok := starColumns(star, catalog)
+			if !ok {
+				return 0, nil, false
+			}
+			count += len(columns)
+			tables = append(tables, names...)
+		}
+		return count, tables, true
+	}
+	return 0, nil, false
+}
+
+// countOf returns n followed by noun, in the plural unless n is 1.
+func countOf(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
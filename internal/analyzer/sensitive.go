@@ -0,0 +1,237 @@
+package analyzer
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/MirrexOne/unqueryvet/internal/sqllex"
+	"github.com/MirrexOne/unqueryvet/pkg/config"
+)
+
+// sensitiveCategory is the category of diagnostics for sensitive columns,
+// which may leak data rather than only waste resources, so that drivers can
+// report them as errors.
+const sensitiveCategory = "sensitive-data"
+
+// returningCategory is the category of diagnostics for RETURNING and OUTPUT
+// stars, which shape the rows a statement writes back rather than a query,
+// so that drivers can filter them on their own.
+const returningCategory = "returning"
+
+// sensitivePattern is an entry of the sensitive columns setting.
+type sensitivePattern struct {
+	// table and column are the lower-cased patterns of the entry
+	table, column string
+}
+
+// sensitivePatterns parses the sensitive columns setting. The column of an
+// entry follows its last dot; an entry without a dot marks a whole table.
+func sensitivePatterns(cfg *config.UnqueryvetSettings) []sensitivePattern {
+	patterns := make([]sensitivePattern, 0, len(cfg.Sensitive.Columns))
+	for _, entry := range cfg.Sensitive.Columns {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		i := strings.LastIndexByte(entry, '.')
+		if i < 0 {
+			patterns = append(patterns, sensitivePattern{table: entry, column: "*"})
+			continue
+		}
+		patterns = append(patterns, sensitivePattern{table: entry[:i], column: entry[i+1:]})
+	}
+	return patterns
+}
+
+// matchesTable reports whether the pattern covers the table written as name,
+// with or without its schema.
+func (p sensitivePattern) matchesTable(name string) bool {
+	name = strings.ToLower(unquoteName(name))
+	if matchGlob(p.table, name) {
+		return true
+	}
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		return matchGlob(p.table, name[i+1:])
+	}
+	return false
+}
+
+// sensitiveSelection is a projection exposing sensitive columns.
+type sensitiveSelection struct {
+	// Offset is the byte offset of the star or column in the query
+	Offset int
+	// Star is set for stars, which are reported instead of the usual warning
+	Star    bool
+	Message string
+}
+
+// findSensitiveSelections returns the stars of query over tables with
+// sensitive columns, including the RETURNING and OUTPUT stars of statements
+// modifying one, and, unless allowed is set, the sensitive columns it
+// names in its projections. Projections of EXISTS subqueries are never
+// returned.
+func findSensitiveSelections(query string, patterns []sensitivePattern, allowed bool, cfg *config.UnqueryvetSettings) []sensitiveSelection {
+	var found []sensitiveSelection
+	for _, star := range findSelectStars(query) {
+		lookup := star
+		if star.Context == contextReturning || star.Context == contextOutput {
+			// RETURNING and OUTPUT stars stand for the modified table, which
+			// SQL Server names inserted and deleted in OUTPUT
+			lookup.From = modifiedTable(query, star.Offset)
+			if strings.EqualFold(star.Qualifier, "inserted") || strings.EqualFold(star.Qualifier, "deleted") {
+				lookup.Qualifier = ""
+			}
+		}
+		if star.Context == contextExists || lookup.From == nil {
+			continue
+		}
+		if columns := sensitiveStarColumns(lookup, patterns, cfg); len(columns) > 0 {
+			found = append(found, sensitiveSelection{
+				Offset:  star.Offset,
+				Star:    true,
+				Message: sensitiveStarMessage(star, columns),
+			})
+		}
+	}
+	if allowed {
+		return found
+	}
+
+	tokens := sqllex.Tokenize(query)
+	for i, tok := range tokens {
+		if !tok.IsKeyword("SELECT") || selectContext(tokens, i) == contextExists {
+			continue
+		}
+		start := skipSelectModifiers(tokens, i+1)
+		items := projectionItems(tokens, start)
+		from := parseFrom(tokens, projectionEnd(start, items))
+		if from == nil {
+			continue
+		}
+		for _, item := range items {
+			if ref, ok := projectionColumn(item); ok {
+				if column, ok := sensitiveColumn(ref, from, patterns, cfg); ok {
+					found = append(found, sensitiveSelection{Offset: ref.Offset, Message: sensitiveColumnMessage(column)})
+				}
+			}
+		}
+	}
+	return found
+}
+
+// sensitiveStarColumns returns the sensitive columns a star stands for, as
+// table.column. Without the columns of a table in the catalog, the table is
+// only considered sensitive through entries naming it without wildcards or
+// marking all of its columns.
+func sensitiveStarColumns(star selectStar, patterns []sensitivePattern, cfg *config.UnqueryvetSettings) []string {
+	refs := star.From.Tables
+	if star.Qualifier != "" {
+		table, ok := qualifiedTable(star.From, star.Qualifier)
+		if !ok {
+			return nil
+		}
+		refs = []tableRef{table}
+	}
+
+	var columns []string
+	for _, ref := range refs {
+		if ref.Name == "" {
+			continue
+		}
+		name := unquoteName(ref.Name)
+		if cfg.Catalog != nil {
+			if table, ok := cfg.Catalog.Table(name); ok {
+				for _, column := range table.Columns {
+					if sensitiveTableColumn(ref, column, patterns) && !slices.Contains(columns, table.Name+"."+column) {
+						columns = append(columns, table.Name+"."+column)
+					}
+				}
+				continue
+			}
+		}
+		for _, p := range patterns {
+			if p.matchesTable(ref.Name) && (p.column == "*" || !strings.ContainsAny(p.table, "*?")) && !slices.Contains(columns, name+"."+p.column) {
+				columns = append(columns, name+"."+p.column)
+			}
+		}
+	}
+	return columns
+}
+
+// sensitiveColumn returns the sensitive column ref names as table.column.
+// An unqualified column of several tables is looked up in the tables that
+// have it according to the catalog, or else in all of them.
+func sensitiveColumn(ref columnRef, from *fromClause, patterns []sensitivePattern, cfg *config.UnqueryvetSettings) (string, bool) {
+	refs := from.Tables
+	if ref.Qualifier != "" {
+		table, ok := qualifiedTable(from, ref.Qualifier)
+		if !ok {
+			return "", false
+		}
+		refs = []tableRef{table}
+	} else if len(refs) > 1 && cfg.Catalog != nil {
+		var having []tableRef
+		for _, table := range refs {
+			if known, ok := cfg.Catalog.Table(unquoteName(table.Name)); ok && known.HasColumn(ref.Name) {
+				having = append(having, table)
+			}
+		}
+		if len(having) > 0 {
+			refs = having
+		}
+	}
+
+	for _, table := range refs {
+		if table.Name != "" && sensitiveTableColumn(table, ref.Name, patterns) {
+			return unquoteName(table.Name) + "." + ref.Name, true
+		}
+	}
+	return "", false
+}
+
+// sensitiveTableColumn reports whether a column of table is sensitive.
+func sensitiveTableColumn(table tableRef, column string, patterns []sensitivePattern) bool {
+	column = strings.ToLower(column)
+	for _, p := range patterns {
+		if p.matchesTable(table.Name) && matchGlob(p.column, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// sensitiveStarMessage returns the message reported for a star exposing
+// sensitive columns.
+func sensitiveStarMessage(star selectStar, columns []string) string {
+	clause := "SELECT"
+	switch star.Context {
+	case contextReturning:
+		clause = "RETURNING"
+	case contextOutput:
+		clause = "OUTPUT"
+	}
+	item := "*"
+	if star.Qualifier != "" {
+		item = star.Qualifier + ".*"
+	}
+	noun := "column"
+	if len(columns) > 1 {
+		noun = "columns"
+	}
+	return "sensitive data: " + clause + " " + item + " exposes " + noun + " " + strings.Join(columns, ", ") +
+		" - explicitly specify the needed columns"
+}
+
+// sensitiveColumnMessage returns the message reported for a sensitive
+// column selected by name.
+func sensitiveColumnMessage(column string) string {
+	return "sensitive data: column " + column + " is selected outside the allowed packages"
+}
+
+// isSensitiveAllowed reports whether the analyzed package may select
+// sensitive columns by name.
+func isSensitiveAllowed(pass *analysis.Pass, cfg *config.UnqueryvetSettings) bool {
+	return matchPackage(cfg.Sensitive.AllowedPackages, pass.Pkg.Path())
+}
@@ -0,0 +1,88 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/MirrexOne/unqueryvet/pkg/config"
+)
+
+// defaultSinks lists the functions and methods known to execute or prepare
+// the SQL passed to them, in the format of the ignored-functions setting.
+// Methods are named receiver-agnostic, so each entry matches pointer and
+// value receivers as well as interface methods.
+var defaultSinks = []string{
+	// database/sql
+	"database/sql.DB.Query", "database/sql.DB.QueryContext",
+	"database/sql.DB.QueryRow", "database/sql.DB.QueryRowContext",
+	"database/sql.DB.Exec", "database/sql.DB.ExecContext",
+	"database/sql.DB.Prepare", "database/sql.DB.PrepareContext",
+	"database/sql.Tx.Query", "database/sql.Tx.QueryContext",
+	"database/sql.Tx.QueryRow", "database/sql.Tx.QueryRowContext",
+	"database/sql.Tx.Exec", "database/sql.Tx.ExecContext",
+	"database/sql.Tx.Prepare", "database/sql.Tx.PrepareContext",
+	"database/sql.Conn.QueryContext", "database/sql.Conn.QueryRowContext",
+	"database/sql.Conn.ExecContext", "database/sql.Conn.PrepareContext",
+
+	// github.com/jmoiron/sqlx
+	"github.com/jmoiron/sqlx.*.Select", "github.com/jmoiron/sqlx.*.SelectContext",
+	"github.com/jmoiron/sqlx.*.Get", "github.com/jmoiron/sqlx.*.GetContext",
+	"github.com/jmoiron/sqlx.*.Queryx", "github.com/jmoiron/sqlx.*.QueryxContext",
+	"github.com/jmoiron/sqlx.*.QueryRowx", "github.com/jmoiron/sqlx.*.QueryRowxContext",
+	"github.com/jmoiron/sqlx.*.NamedQuery", "github.com/jmoiron/sqlx.*.NamedQueryContext",
+	"github.com/jmoiron/sqlx.*.NamedExec", "github.com/jmoiron/sqlx.*.NamedExecContext",
+	"github.com/jmoiron/sqlx.*.MustExec", "github.com/jmoiron/sqlx.*.MustExecContext",
+	"github.com/jmoiron/sqlx.*.Preparex", "github.com/jmoiron/sqlx.*.PreparexContext",
+	"github.com/jmoiron/sqlx.*.PrepareNamed", "github.com/jmoiron/sqlx.*.PrepareNamedContext",
+	"github.com/jmoiron/sqlx.Select", "github.com/jmoiron/sqlx.SelectContext",
+	"github.com/jmoiron/sqlx.Get", "github.com/jmoiron/sqlx.GetContext",
+	"github.com/jmoiron/sqlx.NamedQuery", "github.com/jmoiron/sqlx.NamedQueryContext",
+	"github.com/jmoiron/sqlx.NamedExec", "github.com/jmoiron/sqlx.NamedExecContext",
+	"github.com/jmoiron/sqlx.MustExec", "github.com/jmoiron/sqlx.MustExecContext",
+
+	// github.com/georgysavva/scany
+	"github.com/georgysavva/scany/*.Select", "github.com/georgysavva/scany/*.Get",
+
+	// github.com/jackc/pgx, including pgxpool
+	"github.com/jackc/pgx/v*.Query", "github.com/jackc/pgx/v*.QueryRow",
+	"github.com/jackc/pgx/v*.Exec", "github.com/jackc/pgx/v*.SendBatch",
+	"github.com/jackc/pgx/v*.Prepare", "github.com/jackc/pgx/v*.Batch.Queue",
+
+	// gorm.io/gorm
+	"gorm.io/gorm.DB.Raw", "gorm.io/gorm.DB.Exec",
+
+	// github.com/uptrace/bun
+	"github.com/uptrace/bun.*.QueryContext", "github.com/uptrace/bun.*.QueryRowContext",
+	"github.com/uptrace/bun.*.ExecContext", "github.com/uptrace/bun.*.NewRaw",
+}
+
+// isSinkCall reports whether call invokes a function or method that executes
+// or prepares SQL: a default sink or one added with the sinks setting.
+func isSinkCall(pass *analysis.Pass, call *ast.CallExpr, cfg *config.UnqueryvetSettings) bool {
+	fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if fn == nil {
+		return false
+	}
+	for _, name := range qualifiedNames(fn) {
+		for _, patterns := range [][]string{defaultSinks, cfg.Sinks} {
+			for _, pattern := range patterns {
+				if matchGlob(pattern, name) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isCheckedCall reports whether the arguments of call are checked: calls to
+// ignored functions never are, and in strict mode only sinks are.
+func isCheckedCall(pass *analysis.Pass, call *ast.CallExpr, cfg *config.UnqueryvetSettings) bool {
+	if isIgnoredCall(pass, call, cfg) {
+		return false
+	}
+	return !cfg.StrictSinks || isSinkCall(pass, call, cfg)
+}
@@ -0,0 +1,270 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// sqlSource is SQL text taken from Go source code together with the
+// position of every byte of the text, so that findings inside the query can
+// be reported at the exact character of the Go string literal.
+type sqlSource struct {
+	text string
+	// pos[i] is the position of text[i]
+	pos []token.Pos
+	// start is reported when an offset has no known position
+	start token.Pos
+	// related points at definitions the text was taken from that lie
+	// outside of the analyzed package
+	related []analysis.RelatedInformation
+}
+
+// literalSource returns the value of a string literal with the position of
+// each byte in the literal, taking escape sequences into account.
+func literalSource(lit *ast.BasicLit) sqlSource {
+	src := sqlSource{start: lit.Pos()}
+	raw := lit.Value
+	if len(raw) < 2 {
+		src.text = unquoteSQLLiteral(raw)
+		return src
+	}
+
+	var (
+		text []byte
+		pos  []token.Pos
+	)
+	body, offset := raw[1:len(raw)-1], 1
+	for len(body) > 0 {
+		var (
+			chunk    []byte
+			consumed int
+		)
+		switch {
+		case raw[0] == '`' && body[0] == '\r':
+			// Carriage returns are discarded from raw string literals
+			consumed = 1
+		case raw[0] == '`' || body[0] != '\\':
+			_, consumed = utf8.DecodeRuneInString(body)
+			chunk = []byte(body[:consumed])
+		default:
+			value, multibyte, tail, err := strconv.UnquoteChar(body, raw[0])
+			if err != nil {
+				// Not a valid literal; fall back to the plain normalization rules
+				return sqlSource{text: unquoteSQLLiteral(raw), start: lit.Pos()}
+			}
+			consumed = len(body) - len(tail)
+			if multibyte {
+				chunk = utf8.AppendRune(nil, value)
+			} else {
+				chunk = []byte{byte(value)}
+			}
+		}
+
+		for range chunk {
+			pos = append(pos, lit.Pos()+token.Pos(offset))
+		}
+		text = append(text, chunk...)
+		body, offset = body[consumed:], offset+consumed
+	}
+
+	src.text, src.pos = string(text), pos
+	return src
+}
+
+// posAt returns the source position of the byte at offset in the text.
+func (s sqlSource) posAt(offset int) token.Pos {
+	if offset >= 0 && offset < len(s.pos) && s.pos[offset].IsValid() {
+		return s.pos[offset]
+	}
+	return s.start
+}
+
+// valueSource returns text whose bytes are all attributed to pos.
+func valueSource(text string, pos token.Pos) sqlSource {
+	return sqlSource{text: text, start: pos}
+}
+
+// positions returns the position of every byte of the text.
+func (s sqlSource) positions() []token.Pos {
+	if len(s.pos) == len(s.text) {
+		return s.pos
+	}
+	pos := make([]token.Pos, len(s.text))
+	for i := range pos {
+		pos[i] = s.start
+	}
+	return pos
+}
+
+// equal reports whether s and other have the same text at the same positions.
+func (s sqlSource) equal(other sqlSource) bool {
+	return s.text == other.text && s.start == other.start && slices.Equal(s.positions(), other.positions())
+}
+
+// slice returns the text between offsets i and j with its positions.
+func (s sqlSource) slice(i, j int) sqlSource {
+	return sqlSource{text: s.text[i:j], pos: s.positions()[i:j], start: s.posAt(i)}
+}
+
+// concat returns the concatenation of s and other.
+func (s sqlSource) concat(other sqlSource) sqlSource {
+	pos := make([]token.Pos, 0, len(s.text)+len(other.text))
+	pos = append(pos, s.positions()...)
+	pos = append(pos, other.positions()...)
+
+	related := append(append([]analysis.RelatedInformation(nil), s.related...), other.related...)
+	return sqlSource{text: s.text + other.text, pos: pos, start: s.start, related: related}
+}
+
+// constantSource returns the value of a constant string expression with
+// positions pointing into the literals it is made of. Concatenations are
+// followed operand by operand and named constants declared in the analyzed
+// package are followed to their definition, so findings are reported where
+// the offending text was written. Constants from other packages keep the
+// position of the expression that refers to them.
+//
+// Package-level variables carrying a sqlQueryFact are resolved the same way:
+// variables of the analyzed package through their definition, variables of
+// other packages through the fact, with related information pointing back to
+// the definition.
+func constantSource(pass *analysis.Pass, expr ast.Expr) (sqlSource, bool) {
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return variableSource(pass, expr)
+	}
+
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.BasicLit:
+		return literalSource(e), true
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD {
+			left, lok := constantSource(pass, e.X)
+			right, rok := constantSource(pass, e.Y)
+			if lok && rok {
+				return left.concat(right), true
+			}
+		}
+	case *ast.Ident, *ast.SelectorExpr:
+		if c := referencedConst(pass, e); c != nil {
+			if def := definition(pass, c); def != nil {
+				return constantSource(pass, def)
+			}
+			src := valueSource(constant.StringVal(tv.Value), expr.Pos())
+			if c.Pkg() != pass.Pkg {
+				src.related = []analysis.RelatedInformation{{Pos: c.Pos(), Message: "query defined here"}}
+			}
+			return src, true
+		}
+	}
+
+	return valueSource(constant.StringVal(tv.Value), expr.Pos()), true
+}
+
+// referencedConst returns the named constant an identifier or qualified identifier refers to.
+func referencedConst(pass *analysis.Pass, expr ast.Expr) *types.Const {
+	var ident *ast.Ident
+	switch e := expr.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return nil
+	}
+	c, _ := pass.TypesInfo.Uses[ident].(*types.Const)
+	return c
+}
+
+// variableSource resolves a string expression that is not constant but is
+// built from package-level variables holding SQL text.
+func variableSource(pass *analysis.Pass, expr ast.Expr) (sqlSource, bool) {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD && isStringType(pass.TypesInfo.TypeOf(e)) {
+			left, lok := constantSource(pass, e.X)
+			right, rok := constantSource(pass, e.Y)
+			if lok && rok {
+				return left.concat(right), true
+			}
+		}
+	case *ast.Ident, *ast.SelectorExpr:
+		if v := referencedVar(pass, e); v != nil {
+			return globalSource(pass, v, e)
+		}
+	}
+	return sqlSource{}, false
+}
+
+// globalSource returns the text of a package-level variable holding SQL
+// text, referenced by ref. A nil ref leaves the text without a position.
+func globalSource(pass *analysis.Pass, v *types.Var, ref ast.Expr) (sqlSource, bool) {
+	var fact sqlQueryFact
+	if !pass.ImportObjectFact(v, &fact) {
+		return sqlSource{}, false
+	}
+	if def := definition(pass, v); def != nil {
+		return constantSource(pass, def)
+	}
+
+	pos := token.NoPos
+	if ref != nil {
+		pos = ref.Pos()
+	}
+	src := valueSource(fact.Query, pos)
+	src.related = []analysis.RelatedInformation{{Pos: v.Pos(), Message: "query defined here"}}
+	return src, true
+}
+
+// referencedVar returns the package-level variable an identifier or qualified identifier refers to.
+func referencedVar(pass *analysis.Pass, expr ast.Expr) *types.Var {
+	var ident *ast.Ident
+	switch e := expr.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return nil
+	}
+	v, _ := pass.TypesInfo.Uses[ident].(*types.Var)
+	if v == nil || v.Pkg() == nil || v.Parent() != v.Pkg().Scope() {
+		return nil
+	}
+	return v
+}
+
+// definition returns the expression a constant or package-level variable of
+// the analyzed package is initialized with, or nil if it is declared
+// elsewhere or implicitly.
+func definition(pass *analysis.Pass, obj types.Object) ast.Expr {
+	if obj.Pkg() != pass.Pkg {
+		return nil
+	}
+	for _, file := range pass.Files {
+		if obj.Pos() < file.FileStart || obj.Pos() >= file.FileEnd {
+			continue
+		}
+		path, _ := astutil.PathEnclosingInterval(file, obj.Pos(), obj.Pos())
+		for _, node := range path {
+			spec, ok := node.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, name := range spec.Names {
+				if name.Pos() == obj.Pos() && i < len(spec.Values) && len(spec.Values) == len(spec.Names) {
+					return spec.Values[i]
+				}
+			}
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package analyzer
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/MirrexOne/unqueryvet/internal/sqllex"
+)
+
+// fromClause lists the tables a SELECT reads from.
+type fromClause struct {
+	// Tables lists the tables of the FROM clause and its joins in order
+	Tables []tableRef
+	// Merged is set when NATURAL or USING joins merge columns of the
+	// joined tables, so that a bare star is not their concatenation
+	Merged bool
+}
+
+// tableRef is a table of a FROM clause.
+type tableRef struct {
+	// Name is the table name as written, possibly qualified and quoted.
+	// It is empty for derived tables, table functions, common table
+	// expressions and tables renamed with a column alias list.
+	Name string
+	// Alias is the alias of the table as written, or empty
+	Alias string
+}
+
+// ref returns the name that qualifies the columns of the table in the query.
+func (t tableRef) ref() string {
+	if t.Alias != "" {
+		return t.Alias
+	}
+	return t.Name
+}
+
+// tableKeywords are keywords that may follow a table in a FROM clause and
+// are therefore never taken for its alias.
+var tableKeywords = map[string]bool{
+	"JOIN":          true,
+	"INNER":         true,
+	"LEFT":          true,
+	"RIGHT":         true,
+	"FULL":          true,
+	"OUTER":         true,
+	"CROSS":         true,
+	"NATURAL":       true,
+	"STRAIGHT_JOIN": true,
+	"ON":            true,
+	"USING":         true,
+	"WITH":          true,
+	"TABLESAMPLE":   true,
+	"PARTITION":     true,
+	"USE":           true,
+	"FORCE":         true,
+	"IGNORE":        true,
+	"RETURNING":     true,
+}
+
+// parseFrom parses the FROM clause starting at tokens[i], the token that
+// follows a projection list. It returns nil when there is no FROM clause or
+// when part of it is unknown at analysis time.
+func parseFrom(tokens []sqllex.Token, i int) *fromClause {
+	if i >= len(tokens) || !tokens[i].IsKeyword("FROM") {
+		return nil
+	}
+
+	var (
+		from        = &fromClause{}
+		ctes        = cteNames(tokens)
+		depth       = 0
+		expectTable = true
+	)
+	for i++; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch {
+		case depth == 0 && (tok.IsOperator(")") || tok.IsOperator(";") || isClauseKeyword(tok)):
+			return from
+		case depth == 0 && tok.Kind == sqllex.Opaque:
+			// Tables only known at run time may join anything
+			return nil
+		case expectTable:
+			table, next := parseTableRef(tokens, i)
+			if ctes[strings.ToLower(unquoteName(table.Name))] {
+				table.Name = ""
+			}
+			from.Tables = append(from.Tables, table)
+			expectTable = false
+			i = next - 1
+		case tok.IsOperator("("):
+			depth++
+		case tok.IsOperator(")"):
+			depth--
+		case depth == 0 && (tok.IsOperator(",") || tok.IsKeyword("JOIN") || tok.IsKeyword("STRAIGHT_JOIN")):
+			expectTable = true
+		case depth == 0 && (tok.IsKeyword("NATURAL") || tok.IsKeyword("USING")):
+			from.Merged = true
+		}
+	}
+	return from
+}
+
+// isClauseKeyword reports whether tok starts a clause ending the FROM clause.
+func isClauseKeyword(tok sqllex.Token) bool {
+	if tok.Kind != sqllex.Ident {
+		return false
+	}
+	keyword := strings.ToUpper(tok.Text)
+	return projectionTerminators[keyword] || keyword == "RETURNING"
+}
+
+// parseTableRef parses a table with its optional alias starting at
+// tokens[i] and returns it with the index of the first token after it.
+func parseTableRef(tokens []sqllex.Token, i int) (tableRef, int) {
+	var table tableRef
+	switch {
+	case tokens[i].IsKeyword("LATERAL") || tokens[i].IsOperator("("):
+		// Derived tables keep an empty name
+		if tokens[i].IsKeyword("LATERAL") {
+			i++
+		}
+		i = skipParens(tokens, i)
+	default:
+		table.Name, i = parseQualifiedName(tokens, i)
+		if table.Name == "" {
+			return table, i + 1
+		}
+		if i < len(tokens) && tokens[i].IsOperator("(") {
+			// Table functions have columns of their own
+			table.Name = ""
+			i = skipParens(tokens, i)
+		}
+	}
+
+	if i < len(tokens) && tokens[i].IsKeyword("AS") {
+		i++
+	}
+	if i < len(tokens) && isAlias(tokens[i]) {
+		table.Alias = tokens[i].Text
+		i++
+		if i < len(tokens) && tokens[i].IsOperator("(") {
+			// A column alias list renames the columns of the table
+			table.Name = ""
+			i = skipParens(tokens, i)
+		}
+	}
+	return table, i
+}
+
+// isAlias reports whether tok may be the alias of a table.
+func isAlias(tok sqllex.Token) bool {
+	switch tok.Kind {
+	case sqllex.QuotedIdent:
+		return true
+	case sqllex.Ident:
+		keyword := strings.ToUpper(tok.Text)
+		return !tableKeywords[keyword] && !projectionTerminators[keyword]
+	}
+	return false
+}
+
+// cteNames returns the lower-cased names of the common table expressions
+// defined in tokens, which shadow tables of the same name.
+func cteNames(tokens []sqllex.Token) map[string]bool {
+	var names map[string]bool
+	for i := 0; i+2 < len(tokens); i++ {
+		if tokens[i].Kind != sqllex.Ident && tokens[i].Kind != sqllex.QuotedIdent || i == 0 {
+			continue
+		}
+		if prev := tokens[i-1]; !prev.IsKeyword("WITH") && !prev.IsKeyword("RECURSIVE") && !prev.IsOperator(",") {
+			continue
+		}
+		j := skipParens(tokens, i+1)
+		if j+1 < len(tokens) && tokens[j].IsKeyword("AS") && tokens[j+1].IsOperator("(") {
+			if names == nil {
+				names = make(map[string]bool)
+			}
+			names[strings.ToLower(tokens[i].Name())] = true
+		}
+	}
+	return names
+}
+
+// unquoteName returns a possibly qualified name as written in a query,
+// like "public"."users", with the quotes of each part removed.
+func unquoteName(name string) string {
+	var parts []string
+	for _, tok := range sqllex.Tokenize(name) {
+		if tok.Kind == sqllex.Ident || tok.Kind == sqllex.QuotedIdent {
+			parts = append(parts, tok.Name())
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// modifiedTable returns the table changed by the INSERT, UPDATE, DELETE or
+// MERGE statement owning the token at offset in query, as a FROM clause of
+// its own, or nil when it is unknown.
+func modifiedTable(query string, offset int) *fromClause {
+	tokens := sqllex.Tokenize(query)
+	j := slices.IndexFunc(tokens, func(tok sqllex.Token) bool { return tok.Pos == offset })
+	for depth := 0; j > 0; {
+		j--
+		tok := tokens[j]
+		switch {
+		case tok.IsOperator(";"):
+			return nil
+		case tok.IsOperator(")"):
+			depth++
+		case tok.IsOperator("("):
+			depth--
+			if depth < 0 {
+				return nil
+			}
+		case depth == 0 && (tok.IsKeyword("INSERT") || tok.IsKeyword("UPDATE") || tok.IsKeyword("DELETE") || tok.IsKeyword("MERGE")):
+			i := j + 1
+			for i < len(tokens) && (tokens[i].IsKeyword("INTO") || tokens[i].IsKeyword("FROM") || tokens[i].IsKeyword("ONLY")) {
+				i++
+			}
+			var table tableRef
+			if table.Name, i = parseQualifiedName(tokens, i); table.Name == "" {
+				return nil
+			}
+			if i < len(tokens) && tokens[i].IsKeyword("AS") {
+				i++
+			}
+			if i < len(tokens) && isAlias(tokens[i]) && !tokens[i].IsKeyword("SET") && !tokens[i].IsKeyword("OUTPUT") {
+				table.Alias = tokens[i].Text
+			}
+			return &fromClause{Tables: []tableRef{table}}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+-- Schema used to suggest column lists in the fixes test package
+CREATE TABLE users (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    email TEXT UNIQUE
+);
+
+CREATE TABLE orders (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users (id),
+    total NUMERIC(10, 2),
+    CONSTRAINT orders_total_check CHECK (total >= 0)
+);
+
+CREATE TABLE "audit log" (
+    id BIGINT,
+    "order" TEXT
+);
+
+CREATE TABLE contacts (
+    id BIGINT,
+    "Email" TEXT
+);
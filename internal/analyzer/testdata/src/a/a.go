@@ -71,3 +71,44 @@ func removedNolintDirectives() {
 	anotherQuery := "SELECT * FROM backup" // want "avoid SELECT \\* - explicitly specify needed columns for better performance, maintainability and stability"
 	_ = anotherQuery
 }
+
+// Queries recognized by the SQL tokenizer
+func tokenizerEdgeCases() {
+	distinct := "SELECT  DISTINCT * FROM users" // want "avoid SELECT \\* - explicitly specify needed columns for better performance, maintainability and stability"
+	commented := "SELECT/**/* FROM users"       // want "avoid SELECT \\* - explicitly specify needed columns for better performance, maintainability and stability"
+	escaped := "select\n*\nfrom users"          // want "avoid SELECT \\* - explicitly specify needed columns for better performance, maintainability and stability"
+	_, _, _ = distinct, commented, escaped
+
+	// Star inside a string value or a comment is not a projection
+	quoted := "INSERT INTO audit (query) VALUES ('SELECT * FROM users')"
+	inComment := "SELECT id FROM users -- replaces SELECT * FROM users"
+	product := "SELECT price * quantity FROM orders"
+	_, _, _ = quoted, inComment, product
+}
+
+// Table-qualified star projections
+func qualifiedStars() {
+	joined := "SELECT u.*, o.total FROM users u JOIN orders o ON o.user_id = u.id" // want "avoid SELECT u\\.\\* - explicitly specify needed columns for better performance, maintainability and stability"
+	schema := `SELECT "public"."users".* FROM "public"."users"`                   // want "avoid SELECT \"public\"\\.\"users\"\\.\\* - explicitly specify needed columns"
+	_, _ = joined, schema
+}
+
+// SELECT * in EXISTS subqueries is allowed by default
+func existsSubqueries() {
+	exists := "SELECT id FROM orders o WHERE EXISTS (SELECT * FROM users u WHERE u.id = o.user_id)"
+	notExists := "SELECT id FROM users WHERE NOT EXISTS (SELECT * FROM bans WHERE user_id = id)"
+	_, _ = exists, notExists
+}
+
+// RETURNING * and OUTPUT inserted.* in data-modifying statements
+func returningStars() {
+	db, _ := sql.Open("postgres", "")
+
+	rows, _ := db.Query("INSERT INTO users (name) VALUES ($1) RETURNING *", "john") // want "avoid RETURNING \\* - explicitly specify returned columns"
+	_ = rows
+
+	updated := "UPDATE users SET name = $1 WHERE id = $2 RETURNING *"      // want "avoid RETURNING \\* - explicitly specify returned columns"
+	output := "INSERT INTO users (name) OUTPUT inserted.* VALUES (@name)" // want "avoid OUTPUT inserted\\.\\* - explicitly specify output columns"
+	explicit := "DELETE FROM users WHERE id = $1 RETURNING id"
+	_, _, _ = updated, output, explicit
+}
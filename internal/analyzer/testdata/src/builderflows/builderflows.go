@@ -0,0 +1,115 @@
+// Package builderflows contains SQL builders followed through variables, branches and helpers
+package builderflows
+
+import (
+	"builderhelpers"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// Variables of the same name in different functions are different builders
+func listUsers() string {
+	query := sq.Select()
+	query = query.Columns("id", "name").From("users")
+	sql, _, _ := query.ToSql()
+	return sql
+}
+
+func listOrders() string {
+	query := sq.Select() // want "SQL builder Select\\(\\) without columns defaults to SELECT \\*"
+	query = query.From("orders")
+	sql, _, _ := query.ToSql()
+	return sql
+}
+
+// A shadowed variable is a different builder
+func shadowed(admins bool) string {
+	query := sq.Select("id").From("users")
+	if admins {
+		query := sq.Select() // want "SQL builder Select\\(\\) without columns defaults to SELECT \\*"
+		_, _, _ = query.From("admins").ToSql()
+	}
+	sql, _, _ := query.ToSql()
+	return sql
+}
+
+// A builder missing columns on one path is reported
+func oneBranch(detailed bool) string {
+	query := sq.Select().From("users") // want "SQL builder Select\\(\\) without columns defaults to SELECT \\*"
+	if detailed {
+		query = query.Columns("id", "name", "email")
+	}
+	sql, _, _ := query.ToSql()
+	return sql
+}
+
+// A builder given columns on every path is not
+func everyBranch(detailed bool) string {
+	query := sq.Select().From("users")
+	if detailed {
+		query = query.Columns("id", "name", "email")
+	} else {
+		query = query.Columns("id")
+	}
+	sql, _, _ := query.ToSql()
+	return sql
+}
+
+// Builders are immutable values, a discarded Columns call gives no columns
+func discarded() string {
+	query := sq.Select().From("users") // want "SQL builder Select\\(\\) without columns defaults to SELECT \\*"
+	query.Columns("id")
+	sql, _, _ := query.ToSql()
+	return sql
+}
+
+// Pointer builders are changed in place by their methods
+func pointers(named bool) string {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select()
+	sb.From("users")
+	if named {
+		sb.Select("id", "name")
+	}
+	sql, _ := sb.Build()
+
+	other := sqlbuilder.NewSelectBuilder()
+	other.Select() // want "SQL builder Select\\(\\) without columns defaults to SELECT \\*"
+	other.From("orders")
+	_, _ = other.Build()
+	return sql
+}
+
+// Helpers of the package are followed through their summaries
+func base() sq.SelectBuilder { // want base:"builder returns=true escapes=\\[\\] forwards=\\[\\] completes=\\[\\]"
+	return sq.Select().From("users")
+}
+
+func complete(q sq.SelectBuilder) sq.SelectBuilder { // want complete:"builder returns=false escapes=\\[\\] forwards=\\[\\] completes=\\[\\]"
+	return q.Columns("id")
+}
+
+func run(q sq.SelectBuilder) {
+	_, _, _ = q.ToSql()
+}
+
+func helpers() {
+	run(complete(base()))
+	run(base()) // want "SQL builder Select\\(\\) without columns defaults to SELECT \\*"
+
+	q := sq.Select().From("users") // want "SQL builder Select\\(\\) without columns defaults to SELECT \\*"
+	run(q)
+}
+
+// Helpers of other packages are followed through facts
+func imported() {
+	_, _, _ = builderhelpers.WithColumns(builderhelpers.Users()).ToSql()
+	_, _, _ = builderhelpers.Active(builderhelpers.Users()).ToSql() // want "SQL builder Select\\(\\) without columns defaults to SELECT \\*"
+
+	q := sq.Select().From("orders") // want "SQL builder Select\\(\\) without columns defaults to SELECT \\*"
+	_ = builderhelpers.Render(q)
+
+	done := sq.Select().From("orders")
+	_ = builderhelpers.Render(builderhelpers.WithColumns(done))
+}
@@ -0,0 +1,25 @@
+// Package builderhelpers contains SQL builder helpers used from another package
+package builderhelpers
+
+import sq "github.com/Masterminds/squirrel"
+
+// Users starts a query of users, leaving the columns to the caller
+func Users() sq.SelectBuilder { // want Users:"builder returns=true escapes=\\[\\] forwards=\\[\\] completes=\\[\\]"
+	return sq.Select().From("users")
+}
+
+// WithColumns selects the columns of users
+func WithColumns(q sq.SelectBuilder) sq.SelectBuilder { // want WithColumns:"builder returns=false escapes=\\[\\] forwards=\\[\\] completes=\\[\\]"
+	return q.Columns("id", "name")
+}
+
+// Active keeps active users
+func Active(q sq.SelectBuilder) sq.SelectBuilder { // want Active:"builder returns=false escapes=\\[\\] forwards=\\[0\\] completes=\\[\\]"
+	return q.Where("active")
+}
+
+// Render builds the query
+func Render(q sq.SelectBuilder) string {
+	query, _, _ := q.ToSql()
+	return query
+}
@@ -0,0 +1,74 @@
+// Package builders contains SQL builder calls, resolved by the type of their receiver or package
+package builders
+
+import (
+	"reflect"
+
+	"example.com/qb"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+const star = "*"
+
+// Squirrel calls selecting every column are reported
+func squirrelStars() {
+	_ = sq.Select("*").From("users")                  // want "avoid SELECT \\* in SQL builder"
+	_ = sq.Select(star).From("users")                 // want "avoid SELECT \\* in SQL builder"
+	_ = sq.StatementBuilder.Select("*").From("users") // want "avoid SELECT \\* in SQL builder"
+	_ = sq.Select().Columns("*").From("users")        // want "avoid SELECT \\* in SQL builder"
+	_ = sq.Select("id").Column("*")                   // want "avoid SELECT \\* in SQL builder"
+
+	query := sq.Select() // want "SQL builder Select\\(\\) without columns defaults to SELECT \\*"
+	_ = query.From("users")
+
+	withColumns := sq.Select()
+	_ = withColumns.Columns("id", "name").From("users")
+
+	_ = sq.Select("id", "name").From("users")
+}
+
+// go-sqlbuilder calls are reported through the package function and the builder method
+func sqlbuilderStars() {
+	_ = sqlbuilder.Select("*").From("users")                    // want "avoid SELECT \\* in SQL builder"
+	_ = sqlbuilder.NewSelectBuilder().Select("*").From("users") // want "avoid SELECT \\* in SQL builder"
+	_ = sqlbuilder.Select("id").From("users")
+}
+
+// Repository embeds a builder, its promoted methods are builder methods
+type Repository struct {
+	sq.SelectBuilder
+}
+
+func embedded(r Repository) {
+	_ = r.Columns("*") // want "avoid SELECT \\* in SQL builder"
+}
+
+// In-house builders are checked once registered with the sql-builders setting
+func inHouse() {
+	_ = qb.Select("*").From("users")           // want "avoid SELECT \\* in SQL builder"
+	_ = qb.Select().Columns("*").From("users") // want "avoid SELECT \\* in SQL builder"
+}
+
+// Menu is a UI component whose Select method has nothing to do with SQL
+type Menu struct{}
+
+// Select highlights an item
+func (m *Menu) Select(item string) *Menu { return m }
+
+// Columns lays out the items
+func (m *Menu) Columns(names ...string) *Menu { return m }
+
+// Methods named like builder methods are not reported on other types
+func notBuilders(m *Menu, v reflect.Value) {
+	_ = m.Select("*")
+	_ = m.Columns("*")
+
+	selected := m.Select("")
+	_ = selected
+
+	empty := m.Select
+	_ = empty
+
+	_, _, _ = reflect.Select([]reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: v}})
+}
@@ -0,0 +1,85 @@
+// Package bunqueries contains bun select queries
+package bunqueries
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// User is a bun model
+type User struct {
+	ID   int64  `bun:"id,pk"`
+	Name string `bun:"name"`
+}
+
+// Queries run without columns or a model select every column
+func unprojected(ctx context.Context, db *bun.DB, idb bun.IDB) {
+	var rows []map[string]any
+	_ = db.NewSelect().Table("users").Scan(ctx, &rows)                  // want "bun select query without Column\\(\\) or Model\\(\\) selects every column"
+	_ = idb.NewSelect().Table("users").Where("active").Scan(ctx, &rows) // want "bun select query without Column\\(\\) or Model\\(\\) selects every column"
+	_, _ = db.NewSelect().Table("users").Exec(ctx, &rows)               // want "bun select query without Column\\(\\) or Model\\(\\) selects every column"
+	r, _ := db.NewSelect().Table("users").Rows(ctx)                     // want "bun select query without Column\\(\\) or Model\\(\\) selects every column"
+	_ = r
+}
+
+// Star columns are reported
+func stars(ctx context.Context, db *bun.DB) {
+	var rows []map[string]any
+	_ = db.NewSelect().Table("users").ColumnExpr("*").Scan(ctx, &rows) // want "avoid SELECT \\* in SQL builder"
+	_ = db.NewSelect().Table("users").Column("*").Scan(ctx, &rows)     // want "avoid SELECT \\* in SQL builder"
+}
+
+// Queries with columns or a model are not reported
+func projected(ctx context.Context, db *bun.DB) {
+	var user User
+	_ = db.NewSelect().Model(&user).Where("id = ?", 1).Scan(ctx)
+	_ = db.NewSelect().Model(&user).ExcludeColumn("name").Scan(ctx)
+
+	var ids []int64
+	_ = db.NewSelect().Table("users").Column("id").Scan(ctx, &ids)
+	_ = db.NewSelect().Table("users").ColumnExpr("max(id)").Scan(ctx, &ids)
+
+	count, _ := db.NewSelect().Table("users").Count(ctx)
+	_ = count
+}
+
+// Queries are followed through local variables, and their methods change the query
+func variables(ctx context.Context, db *bun.DB, limit bool) {
+	var rows []map[string]any
+
+	q := db.NewSelect().Table("users")
+	if limit {
+		q = q.Limit(10)
+	}
+	_ = q.Scan(ctx, &rows) // want "bun select query without Column\\(\\) or Model\\(\\) selects every column"
+
+	withColumns := db.NewSelect().Table("users")
+	withColumns.Column("id", "name")
+	_ = withColumns.Scan(ctx, &rows)
+}
+
+// Queries received as parameters are reported where the caller passes them
+func scan(ctx context.Context, q *bun.SelectQuery, dest any) error { // want scan:"builder returns=false escapes=\\[1\\] forwards=\\[\\] completes=\\[\\]"
+	return q.Scan(ctx, dest)
+}
+
+func helpers(ctx context.Context, db *bun.DB) {
+	var ids []int64
+	_ = scan(ctx, db.NewSelect().Table("users").Column("id"), &ids)
+	_ = scan(ctx, db.NewSelect().Table("users"), &ids) // want "bun select query without Column\\(\\) or Model\\(\\) selects every column"
+
+	// Columns given to a query passed as a parameter are seen by the caller
+	q := db.NewSelect().Table("users")
+	withID(q)
+	_ = q.Scan(ctx, &ids)
+}
+
+func withID(q *bun.SelectQuery) { // want withID:"builder returns=false escapes=\\[\\] forwards=\\[\\] completes=\\[0\\]"
+	q.Column("id")
+}
+
+// Functions the package never calls may get queries from anywhere and report them at use
+func scanAll(ctx context.Context, q *bun.SelectQuery, dest any) error {
+	return q.Scan(ctx, dest) // want "bun select query without Column\\(\\) or Model\\(\\) selects every column"
+}
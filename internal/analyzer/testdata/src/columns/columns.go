@@ -0,0 +1,39 @@
+// Package columns contains queries naming columns checked against a schema
+package columns
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Columns missing from known tables are reported with the closest column
+func literals(db *sql.DB) {
+	_, _ = db.Query("SELECT id, emial FROM users")                                          // want `column "emial" does not exist in users - did you mean "email"\?`
+	_, _ = db.Query("SELECT u.id, u.nmae AS name FROM users u")                             // want `column "u.nmae" does not exist in users - did you mean "name"\?`
+	_, _ = db.Query("SELECT id, total, status FROM orders")                                 // want `column "status" does not exist in orders`
+	_, _ = db.Query("SELECT u.name, o.totl FROM users u JOIN orders o ON o.user_id = u.id") // want `column "o.totl" does not exist in orders - did you mean "total"\?`
+	_, _ = db.Query("SELECT name, totl FROM users, orders")                                 // want `column "totl" does not exist in users, orders - did you mean "total"\?`
+}
+
+// Known columns, expressions and unknown tables are not reported
+func valid(db *sql.DB, table string) {
+	_, _ = db.Query("SELECT id, name, email FROM users")
+	_, _ = db.Query("SELECT \"id\", users.email, public.users.name FROM public.users")
+	_, _ = db.Query("SELECT count(*), 1, NULL, lower(name), id + 1 AS next FROM users")
+	_, _ = db.Query("SELECT id, status FROM accounts")
+	_, _ = db.Query("SELECT id, name FROM (SELECT id, name FROM users) t")
+	_, _ = db.Query("SELECT id FROM users WHERE EXISTS (SELECT total FROM orders WHERE user_id = users.id)")
+	_, _ = db.Query("SELECT id, slug FROM users WHERE id = $1", 1) // want `column "slug" does not exist in users`
+	_, _ = db.Query("SELECT id, status FROM " + table)
+}
+
+// Builder columns are checked against the tables of the chain
+func builders() {
+	_, _, _ = sq.Select("id", "emial").From("users").ToSql()                                           // want `column "emial" does not exist in users - did you mean "email"\?`
+	_, _, _ = sq.Select("id").Columns("name", "nmae").From("users").ToSql()                            // want `column "nmae" does not exist in users - did you mean "name"\?`
+	_, _, _ = sq.Select("u.id", "o.totl").From("users u").Join("orders o ON o.user_id = u.id").ToSql() // want `column "o.totl" does not exist in orders - did you mean "total"\?`
+	_, _, _ = sq.Select("id", "name").From("users").Where("id = ?", 1).ToSql()
+	_, _, _ = sq.Select("id", "status").From("accounts").ToSql()
+	_, _, _ = sq.Select("id", "status").ToSql()
+}
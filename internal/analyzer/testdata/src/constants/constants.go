@@ -0,0 +1,66 @@
+// Package constants contains queries held in constants and constant expressions
+package constants
+
+import (
+	"database/sql"
+
+	"queries"
+)
+
+const allOrders = "SELECT * FROM orders" // want "avoid SELECT \\* - explicitly specify needed columns" allOrders:`sql "SELECT \* FROM orders"`
+
+const (
+	ordersTable   = "orders"
+	selectOrders  = "SELECT id, total FROM " + ordersTable // want selectOrders:`sql "SELECT id, total FROM orders"`
+	aliasedOrders = allOrders                              // want aliasedOrders:`sql "SELECT \* FROM orders"`
+)
+
+var userQuery = "SELECT * FROM users" // want "avoid SELECT \\* - explicitly specify needed columns" userQuery:`sql "SELECT \* FROM users"`
+
+// Named constants are reported once, where they are defined
+func namedConstants(db *sql.DB) {
+	rows, _ := db.Query(allOrders)
+	_ = rows
+	rows, _ = db.Query(aliasedOrders)
+	_ = rows
+	rows, _ = db.Query(selectOrders)
+	_ = rows
+	_ = userQuery
+}
+
+// Constant concatenations are resolved operand by operand
+func concatenations(db *sql.DB) {
+	rows, _ := db.Query("SELECT * " + "FROM users") // want "avoid SELECT \\* - explicitly specify needed columns"
+	_ = rows
+
+	query := "SELECT id " + "FROM users WHERE id IN (SELECT * FROM admins)" // want "avoid SELECT \\* in subquery"
+	_ = query
+
+	rows, _ = db.Query("SELECT " + queries.UserColumns + " FROM users")
+	_ = rows
+}
+
+// Constants from other packages are reported where they are used
+func importedConstants(db *sql.DB) {
+	rows, _ := db.Query(queries.AllUsers) // want "avoid SELECT \\* - explicitly specify needed columns"
+	_ = rows
+
+	rows, _ = db.Query("SELECT " + queries.Star + " FROM users") // want "avoid SELECT \\* - explicitly specify needed columns"
+	_ = rows
+}
+
+// Package-level variables from other packages are followed through their facts
+func importedVariables(db *sql.DB) {
+	rows, _ := db.Query(queries.ActiveUsers) // want "avoid SELECT \\* - explicitly specify needed columns"
+	_ = rows
+
+	rows, _ = db.Query(queries.SortedUsers + " LIMIT 10") // want "avoid SELECT \\* - explicitly specify needed columns"
+	_ = rows
+
+	query := queries.ActiveUsers
+	rows, _ = db.Query(query) // want "avoid SELECT \\* - explicitly specify needed columns"
+	_ = rows
+
+	rows, _ = db.Query(queries.Current)
+	_ = rows
+}
@@ -0,0 +1,14 @@
+// Package qb is an in-house query builder registered with the sql-builders setting
+package qb
+
+// Query is a SELECT statement under construction
+type Query struct{}
+
+// Select starts a SELECT statement
+func Select(columns ...string) *Query { return &Query{} }
+
+// Columns adds result columns
+func (q *Query) Columns(columns ...string) *Query { return q }
+
+// From sets the FROM clause
+func (q *Query) From(table string) *Query { return q }
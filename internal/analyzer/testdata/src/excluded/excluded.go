@@ -0,0 +1,8 @@
+// Package excluded has files excluded by the exclude-files setting
+package excluded
+
+// Files that are not excluded are analyzed as usual
+func handwritten() {
+	query := "SELECT * FROM users" // want "avoid SELECT \\* - explicitly specify needed columns"
+	_ = query
+}
@@ -0,0 +1,8 @@
+// Package exists contains SELECT * inside EXISTS subqueries
+package exists
+
+func existsSubqueries() {
+	exists := "SELECT id FROM orders o WHERE EXISTS (SELECT * FROM users u WHERE u.id = o.user_id)" // want "avoid SELECT \\* in EXISTS subquery"
+	notExists := "SELECT id FROM users WHERE NOT EXISTS (SELECT * FROM bans WHERE user_id = id)"    // want "avoid SELECT \\* in EXISTS subquery"
+	_, _ = exists, notExists
+}
@@ -0,0 +1,33 @@
+// Package fixes contains queries whose stars are expanded from a schema
+package fixes
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+const allOrders = "SELECT * FROM orders" // want "avoid SELECT \\* - explicitly specify needed columns" allOrders:`sql "SELECT \* FROM orders"`
+
+// Stars of known tables are replaced with their columns
+func known(db *sql.DB) {
+	_, _ = db.Query("SELECT * FROM users WHERE id = $1", 1)                      // want "avoid SELECT \\* - explicitly specify needed columns"
+	_, _ = db.Query("SELECT DISTINCT * FROM public.users")                       // want "avoid SELECT \\* - explicitly specify needed columns"
+	_, _ = db.Query("SELECT u.* FROM users u JOIN orders o ON o.user_id = u.id") // want "avoid SELECT u.\\* - explicitly specify needed columns"
+	_, _ = db.Query("SELECT * FROM users u, orders AS o")                        // want "avoid SELECT \\* - explicitly specify needed columns"
+	_, _ = db.Query("SELECT id FROM users WHERE id IN (SELECT * FROM orders)")   // want "avoid SELECT \\* in subquery"
+	_, _ = db.Query(allOrders)
+	_, _ = db.Query(fmt.Sprintf("SELECT %s FROM users", "*")) // want "avoid SELECT \\* - explicitly specify needed columns"
+}
+
+// Stars that cannot be expanded keep the diagnostic without a fix
+func unknown(db *sql.DB, table string) {
+	_, _ = db.Query("SELECT * FROM accounts")                              // want "avoid SELECT \\* - explicitly specify needed columns"
+	_, _ = db.Query("SELECT * FROM \"audit log\"")                         // want "avoid SELECT \\* - explicitly specify needed columns"
+	_, _ = db.Query("SELECT * FROM contacts")                              // want "avoid SELECT \\* - explicitly specify needed columns"
+	_, _ = db.Query("SELECT * FROM users JOIN orders USING (id)")          // want "avoid SELECT \\* - explicitly specify needed columns"
+	_, _ = db.Query("SELECT * FROM (SELECT id FROM users) t")              // want "avoid SELECT \\* - explicitly specify needed columns"
+	_, _ = db.Query("WITH users AS (SELECT 1 AS one) SELECT * FROM users") // want "avoid SELECT \\* - explicitly specify needed columns"
+	_, _ = db.Query(fmt.Sprintf("SELECT * FROM %s", table))                // want "avoid SELECT \\* - explicitly specify needed columns"
+	_, _ = db.Query("SELECT \x2a FROM users")                              // want "avoid SELECT \\* - explicitly specify needed columns"
+	_, _ = db.Query("SELECT id FROM users WHERE EXISTS (SELECT * FROM orders)")
+}
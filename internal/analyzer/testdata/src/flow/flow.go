@@ -0,0 +1,116 @@
+// Package flow contains queries assembled in local variables before they reach a call
+package flow
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+)
+
+// Queries written piece by piece into a strings.Builder
+func builder(db *sql.DB) {
+	var sb strings.Builder
+	sb.WriteString("SELECT ")
+	sb.WriteString("* FROM users") // want "avoid SELECT \\* - explicitly specify needed columns"
+	sb.WriteString(" WHERE active")
+	rows, _ := db.Query(sb.String())
+	_ = rows
+}
+
+// Queries written into a bytes.Buffer
+func buffer(db *sql.DB, table string) {
+	var buf bytes.Buffer
+	buf.WriteString("SELECT id FROM users WHERE id IN (SELECT ")
+	buf.WriteString("* FROM ") // want "avoid SELECT \\* in subquery"
+	buf.WriteString(table)
+	buf.WriteByte(')')
+	rows, _ := db.Query(buf.String())
+	_ = rows
+}
+
+// Writes of exclusive branches are followed separately
+func builderBranches(db *sql.DB, admin bool) {
+	var sb strings.Builder
+	if admin {
+		sb.WriteString("SELECT * FROM admins") // want "avoid SELECT \\* - explicitly specify needed columns"
+	} else {
+		sb.WriteString("SELECT id FROM users")
+	}
+	rows, _ := db.Query(sb.String())
+	_ = rows
+
+	var columns strings.Builder
+	columns.WriteString("SELECT ")
+	if admin {
+		columns.WriteString("id, role")
+	} else {
+		columns.WriteString("id")
+	}
+	columns.WriteString(" FROM users")
+	rows, _ = db.Query(columns.String())
+	_ = rows
+}
+
+// Writes in a loop are followed through its iterations
+func builderLoop(db *sql.DB, columns []string) {
+	var sb strings.Builder
+	sb.WriteString("SELECT id")
+	for range columns {
+		sb.WriteString(", *") // want "avoid SELECT \\* - explicitly specify needed columns"
+	}
+	sb.WriteString(" FROM users")
+	rows, _ := db.Query(sb.String())
+	_ = rows
+}
+
+// Queries completed at run time
+func concatenation(db *sql.DB, table string) {
+	query := "SELECT * FROM " + table // want "avoid SELECT \\* - explicitly specify needed columns"
+	rows, _ := db.Query(query)
+	_ = rows
+}
+
+// Queries that differ by branch
+func branches(db *sql.DB, admin bool) {
+	query := "SELECT"
+	if admin {
+		query += " * FROM admins" // want "avoid SELECT \\* - explicitly specify needed columns"
+	} else {
+		query += " id, name FROM users"
+	}
+	rows, _ := db.Query(query)
+	_ = rows
+}
+
+// The same query assigned in several branches is reported at each literal only
+func repeatedLiterals(db *sql.DB, admin bool) {
+	var query string
+	if admin {
+		query = "SELECT * FROM admins" // want "avoid SELECT \\* - explicitly specify needed columns"
+	} else {
+		query = "SELECT * FROM admins" // want "avoid SELECT \\* - explicitly specify needed columns"
+	}
+	rows, _ := db.Query(query)
+	_ = rows
+}
+
+// Columns unknown at analysis time are not reported
+func unknownColumns(db *sql.DB, columns string) {
+	query := "SELECT " + columns + " FROM users"
+	rows, _ := db.Query(query)
+	_ = rows
+
+	var sb strings.Builder
+	sb.WriteString("SELECT ")
+	sb.WriteString(strings.Join([]string{"id", "name"}, ", "))
+	sb.WriteString(" FROM users")
+	rows, _ = db.Query(sb.String())
+	_ = rows
+}
+
+// Multiplication is not a projection star
+func arithmetic(db *sql.DB, factor string) {
+	query := "SELECT price * " + factor + " FROM items"
+	rows, _ := db.Query(query)
+	_ = rows
+}
@@ -0,0 +1,55 @@
+// Package format contains queries built from printf-style format strings
+package format
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Stars written in the format itself
+func formatStars(db *sql.DB, table string) {
+	rows, _ := db.Query(fmt.Sprintf("SELECT * FROM %s", table)) // want "avoid SELECT \\* - explicitly specify needed columns"
+	_ = rows
+}
+
+// Stars passed as the column argument
+func injectedStars(db *sql.DB, w *strings.Builder) {
+	query := fmt.Sprintf("SELECT %s FROM users", "*") // want "avoid SELECT \\* - explicitly specify needed columns"
+	rows, _ := db.Query(query)
+	_ = rows
+
+	columns := "*" // want "avoid SELECT \\* - explicitly specify needed columns"
+	rows, _ = db.Query(fmt.Sprintf("SELECT %v FROM orders", columns))
+	_ = rows
+
+	fmt.Fprintf(w, "SELECT id FROM users WHERE id IN (SELECT %s FROM admins)", "*") // want "avoid SELECT \\* in subquery"
+
+	indexed := fmt.Sprintf("SELECT %[2]s FROM %[1]s", "users", "*") // want "avoid SELECT \\* - explicitly specify needed columns"
+	_ = indexed
+}
+
+// Printf wrappers format their arguments the same way
+func logf(format string, args ...any) {
+	log.Printf(format, args...)
+}
+
+func wrappers() {
+	logf("SELECT %s FROM audit", "*") // want "avoid SELECT \\* - explicitly specify needed columns"
+}
+
+// Opaque verbs and unknown arguments are never stars
+func opaqueArguments(db *sql.DB, cols []string, factor int, name string, err error) {
+	rows, _ := db.Query(fmt.Sprintf("SELECT %s FROM users", strings.Join(cols, ", ")))
+	_ = rows
+
+	rows, _ = db.Query(fmt.Sprintf("SELECT %d * price FROM items", factor))
+	_ = rows
+
+	rows, _ = db.Query(fmt.Sprintf("SELECT id FROM users WHERE name LIKE '%%%s%%'", name))
+	_ = rows
+
+	// Errors are not queries
+	_ = fmt.Errorf("SELECT %s FROM users: %w", "*", err)
+}
@@ -0,0 +1,35 @@
+// Package squirrel is a minimal stub of github.com/Masterminds/squirrel for tests
+package squirrel
+
+// SelectBuilder builds SELECT statements
+type SelectBuilder struct{}
+
+// Select starts a SELECT statement
+func Select(columns ...string) SelectBuilder { return SelectBuilder{} }
+
+// Columns adds result columns
+func (b SelectBuilder) Columns(columns ...string) SelectBuilder { return b }
+
+// Column adds a result column expression
+func (b SelectBuilder) Column(column any, args ...any) SelectBuilder { return b }
+
+// From sets the FROM clause
+func (b SelectBuilder) From(from string) SelectBuilder { return b }
+
+// Join adds a JOIN clause
+func (b SelectBuilder) Join(join string, rest ...any) SelectBuilder { return b }
+
+// Where adds a condition
+func (b SelectBuilder) Where(pred any, args ...any) SelectBuilder { return b }
+
+// ToSql builds the query
+func (b SelectBuilder) ToSql() (string, []any, error) { return "", nil, nil }
+
+// StatementBuilderType starts statements with shared options
+type StatementBuilderType struct{}
+
+// StatementBuilder is the default statement builder
+var StatementBuilder StatementBuilderType
+
+// Select starts a SELECT statement
+func (b StatementBuilderType) Select(columns ...string) SelectBuilder { return SelectBuilder{} }
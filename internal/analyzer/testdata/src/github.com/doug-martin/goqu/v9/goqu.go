@@ -0,0 +1,100 @@
+// Package goqu is a minimal stub of github.com/doug-martin/goqu/v9 for tests
+package goqu
+
+import "context"
+
+// Expression is a SQL expression
+type Expression interface{}
+
+// LiteralExpression is a literal SQL fragment
+type LiteralExpression interface{ Expression }
+
+// Star returns the * projection
+func Star() LiteralExpression { return nil }
+
+// L returns a literal SQL fragment
+func L(sql string, args ...any) LiteralExpression { return nil }
+
+// C returns a column identifier
+func C(col string) Expression { return nil }
+
+// COUNT returns a COUNT aggregate
+func COUNT(col any) Expression { return nil }
+
+// Ex is a map of conditions
+type Ex map[string]any
+
+// Record is a map of values
+type Record map[string]any
+
+// QueryExecutor runs a query
+type QueryExecutor struct{}
+
+// ScanStructs scans every row into structs
+func (q QueryExecutor) ScanStructs(i any) error { return nil }
+
+// SelectDataset builds SELECT statements
+type SelectDataset struct{}
+
+// From starts a SELECT statement
+func From(table ...any) *SelectDataset { return &SelectDataset{} }
+
+// Select starts a SELECT statement with columns
+func Select(cols ...any) *SelectDataset { return &SelectDataset{} }
+
+// Select sets the columns
+func (sd *SelectDataset) Select(selects ...any) *SelectDataset { return sd }
+
+// SelectAppend appends columns to the current projection
+func (sd *SelectDataset) SelectAppend(selects ...any) *SelectDataset { return sd }
+
+// ClearSelect resets the projection to *
+func (sd *SelectDataset) ClearSelect() *SelectDataset { return sd }
+
+// From sets the FROM clause
+func (sd *SelectDataset) From(from ...any) *SelectDataset { return sd }
+
+// Where adds conditions
+func (sd *SelectDataset) Where(expressions ...Expression) *SelectDataset { return sd }
+
+// Order sets the order
+func (sd *SelectDataset) Order(order ...any) *SelectDataset { return sd }
+
+// ToSQL builds the query
+func (sd *SelectDataset) ToSQL() (string, []any, error) { return "", nil, nil }
+
+// Executor returns an executor of the query
+func (sd *SelectDataset) Executor() QueryExecutor { return QueryExecutor{} }
+
+// ScanStructs scans every row into structs, selecting their columns
+func (sd *SelectDataset) ScanStructs(i any) error { return nil }
+
+// ScanStruct scans a row into a struct, selecting its columns
+func (sd *SelectDataset) ScanStruct(i any) (bool, error) { return false, nil }
+
+// ScanVals scans the first column of every row
+func (sd *SelectDataset) ScanVals(i any) error { return nil }
+
+// ScanValsContext scans the first column of every row
+func (sd *SelectDataset) ScanValsContext(ctx context.Context, i any) error { return nil }
+
+// Count counts the rows
+func (sd *SelectDataset) Count() (int64, error) { return 0, nil }
+
+// UpdateDataset builds UPDATE statements
+type UpdateDataset struct{}
+
+// Update starts an UPDATE statement
+func Update(table any) *UpdateDataset { return &UpdateDataset{} }
+
+// Set sets the values
+func (ud *UpdateDataset) Set(values any) *UpdateDataset { return ud }
+
+// ToSQL builds the statement
+func (ud *UpdateDataset) ToSQL() (string, []any, error) { return "", nil, nil }
+
+// Database runs datasets against a database
+type Database struct{}
+
+// From starts a SELECT statement
+func (d *Database) From(from ...any) *SelectDataset { return &SelectDataset{} }
@@ -0,0 +1,18 @@
+// Package sqlscan is a minimal stub of github.com/georgysavva/scany/v2/sqlscan for tests
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Querier runs queries
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Select runs a query and scans every row into dst
+func Select(ctx context.Context, db Querier, dst any, query string, args ...any) error { return nil }
+
+// Get runs a query and scans a single row into dst
+func Get(ctx context.Context, db Querier, dst any, query string, args ...any) error { return nil }
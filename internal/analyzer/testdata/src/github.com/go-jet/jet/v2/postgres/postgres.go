@@ -0,0 +1,38 @@
+// Package postgres is a minimal stub of github.com/go-jet/jet/v2/postgres for tests
+package postgres
+
+// Projection is a projection of a SELECT statement
+type Projection interface{}
+
+// Expression is a SQL expression
+type Expression interface{ Projection }
+
+// ColumnList is a list of columns
+type ColumnList []Projection
+
+// STAR is the * projection
+var STAR Expression
+
+// COUNT returns a COUNT aggregate
+func COUNT(expression Expression) Expression { return nil }
+
+// String returns a string literal
+func String(value string) Expression { return nil }
+
+// SelectStatement is a SELECT statement
+type SelectStatement interface {
+	FROM(tables ...ReadableTable) SelectStatement
+	WHERE(expression Expression) SelectStatement
+	Sql() (query string, args []any)
+}
+
+// ReadableTable is a table rows are selected from
+type ReadableTable interface {
+	SELECT(projection Projection, projections ...Projection) SelectStatement
+}
+
+// Table is a generated table
+type Table struct{ ReadableTable }
+
+// SELECT starts a SELECT statement
+func SELECT(projection Projection, projections ...Projection) SelectStatement { return nil }
@@ -0,0 +1,20 @@
+// Package sqlbuilder is a minimal stub of github.com/huandu/go-sqlbuilder for tests
+package sqlbuilder
+
+// SelectBuilder builds SELECT statements
+type SelectBuilder struct{}
+
+// NewSelectBuilder creates a SELECT builder
+func NewSelectBuilder() *SelectBuilder { return &SelectBuilder{} }
+
+// Select sets the result columns
+func (sb *SelectBuilder) Select(col ...string) *SelectBuilder { return sb }
+
+// From sets the FROM clause
+func (sb *SelectBuilder) From(table ...string) *SelectBuilder { return sb }
+
+// Select starts a SELECT statement
+func Select(col ...string) *SelectBuilder { return NewSelectBuilder().Select(col...) }
+
+// Build builds the query
+func (sb *SelectBuilder) Build() (string, []any) { return "", nil }
@@ -0,0 +1,31 @@
+// Package pgx is a minimal stub of github.com/jackc/pgx/v5 for tests
+package pgx
+
+import "context"
+
+// Conn is a connection to a PostgreSQL server
+type Conn struct{}
+
+// Rows is the result set of a query
+type Rows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Close()
+}
+
+// Row is a single row of a result set
+type Row interface {
+	Scan(dest ...any) error
+}
+
+// Query runs a query
+func (c *Conn) Query(ctx context.Context, sql string, args ...any) (Rows, error) { return nil, nil }
+
+// QueryRow runs a query returning a single row
+func (c *Conn) QueryRow(ctx context.Context, sql string, args ...any) Row { return nil }
+
+// Batch queues queries to send at once
+type Batch struct{}
+
+// Queue adds a query to the batch
+func (b *Batch) Queue(query string, arguments ...any) {}
@@ -0,0 +1,53 @@
+// Package sqlx is a minimal stub of github.com/jmoiron/sqlx for tests
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DB wraps sql.DB
+type DB struct {
+	*sql.DB
+}
+
+// Select runs a query and scans every row into dest
+func (db *DB) Select(dest any, query string, args ...any) error { return nil }
+
+// Get runs a query and scans a single row into dest
+func (db *DB) Get(dest any, query string, args ...any) error { return nil }
+
+// Rows wraps sql.Rows
+type Rows struct {
+	*sql.Rows
+}
+
+// Queryx runs a query
+func (db *DB) Queryx(query string, args ...any) (*Rows, error) { return nil, nil }
+
+// NamedQuery runs a query with named parameters
+func (db *DB) NamedQuery(query string, arg any) (*Rows, error) { return nil, nil }
+
+// Queryer runs queries
+type Queryer interface {
+	Queryx(query string, args ...any) (*Rows, error)
+}
+
+// Select runs a query with q and scans every row into dest
+func Select(q Queryer, dest any, query string, args ...any) error { return nil }
+
+// SelectContext runs a query and scans every row into dest
+func (db *DB) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	return nil
+}
+
+// Row wraps sql.Row
+type Row struct {
+	*sql.Row
+}
+
+// QueryRowx runs a query returning a single row
+func (db *DB) QueryRowx(query string, args ...any) *Row { return nil }
+
+// StructScan scans the row into the struct dest
+func (r *Row) StructScan(dest any) error { return nil }
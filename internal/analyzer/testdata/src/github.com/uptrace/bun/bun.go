@@ -0,0 +1,66 @@
+// Package bun is a minimal stub of github.com/uptrace/bun for tests
+package bun
+
+import (
+	"context"
+	"database/sql"
+)
+
+// IDB is the common interface of DB, Conn and Tx
+type IDB interface {
+	NewSelect() *SelectQuery
+}
+
+// DB is a bun database handle
+type DB struct{}
+
+// NewSelect starts a SELECT query
+func (db *DB) NewSelect() *SelectQuery { return &SelectQuery{} }
+
+// SelectQuery builds and runs SELECT queries
+type SelectQuery struct{}
+
+// Model sets the model, whose fields are selected
+func (q *SelectQuery) Model(model any) *SelectQuery { return q }
+
+// Table sets the table
+func (q *SelectQuery) Table(tables ...string) *SelectQuery { return q }
+
+// Column adds columns
+func (q *SelectQuery) Column(columns ...string) *SelectQuery { return q }
+
+// ColumnExpr adds a column expression
+func (q *SelectQuery) ColumnExpr(query string, args ...any) *SelectQuery { return q }
+
+// ExcludeColumn excludes columns of the model
+func (q *SelectQuery) ExcludeColumn(columns ...string) *SelectQuery { return q }
+
+// Where adds a condition
+func (q *SelectQuery) Where(query string, args ...any) *SelectQuery { return q }
+
+// Limit sets the limit
+func (q *SelectQuery) Limit(n int) *SelectQuery { return q }
+
+// Scan runs the query and scans the result into dest
+func (q *SelectQuery) Scan(ctx context.Context, dest ...any) error { return nil }
+
+// Exec runs the query
+func (q *SelectQuery) Exec(ctx context.Context, dest ...any) (sql.Result, error) { return nil, nil }
+
+// Rows runs the query and returns the rows
+func (q *SelectQuery) Rows(ctx context.Context) (*sql.Rows, error) { return nil, nil }
+
+// Count counts the rows
+func (q *SelectQuery) Count(ctx context.Context) (int, error) { return 0, nil }
+
+// BaseModel carries the table name of a model in its bun tag
+type BaseModel struct{}
+
+// NewRaw starts a raw query
+func (db *DB) NewRaw(query string, args ...any) *RawQuery { return &RawQuery{} }
+
+// RawQuery runs raw SQL
+type RawQuery struct{}
+
+// Scan runs the query and scans the rows into dest
+func (q *RawQuery) Scan(ctx context.Context, dest ...any) error { return nil }
@@ -0,0 +1,92 @@
+// Package goqudatasets contains goqu select datasets
+package goqudatasets
+
+import (
+	"context"
+
+	"github.com/doug-martin/goqu/v9"
+)
+
+// User is a goqu record
+type User struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+// Datasets rendered without a projection select every column
+func unprojected(ctx context.Context, db *goqu.Database) {
+	_, _, _ = goqu.From("users").ToSQL()                                // want "goqu dataset without Select\\(\\) renders SELECT \\*"
+	_, _, _ = goqu.From("users").Where(goqu.Ex{"active": true}).ToSQL() // want "goqu dataset without Select\\(\\) renders SELECT \\*"
+	_ = db.From("users").Executor()                                     // want "goqu dataset without Select\\(\\) renders SELECT \\*"
+	_, _, _ = goqu.From("users").Select("id").ClearSelect().ToSQL()     // want "goqu dataset without Select\\(\\) renders SELECT \\*"
+
+	var ids []int
+	_ = db.From("users").ScanVals(&ids)             // want "goqu dataset without Select\\(\\) renders SELECT \\*"
+	_ = db.From("users").ScanValsContext(ctx, &ids) // want "goqu dataset without Select\\(\\) renders SELECT \\*"
+}
+
+// Star projections are reported where they are selected
+func stars() {
+	_, _, _ = goqu.From("users").Select(goqu.Star()).ToSQL()       // want "avoid SELECT \\* in SQL builder"
+	_, _, _ = goqu.From("users").Select("id", goqu.L("*")).ToSQL() // want "avoid SELECT \\* in SQL builder"
+	_, _, _ = goqu.Select(goqu.Star()).From("users").ToSQL()       // want "avoid SELECT \\* in SQL builder"
+
+	// COUNT(*) selects no columns
+	_, _, _ = goqu.From("users").Select(goqu.COUNT(goqu.Star())).ToSQL()
+}
+
+// Datasets with a projection, struct scans and other statements are not reported
+func projected(db *goqu.Database) {
+	_, _, _ = goqu.From("users").Select("id", "name").ToSQL()
+	_, _, _ = goqu.Select(goqu.C("id")).From("users").ToSQL()
+	_ = db.From("users").Select("id").Executor()
+
+	var users []User
+	_ = db.From("users").ScanStructs(&users)
+
+	var count int64
+	count, _ = db.From("users").Count()
+	_ = count
+
+	_, _, _ = goqu.Update("users").Set(goqu.Record{"name": "x"}).ToSQL()
+}
+
+// Datasets are followed through local variables
+func variables(admin bool) {
+	ds := goqu.From("users")
+	if admin {
+		ds = ds.Where(goqu.Ex{"admin": true})
+	}
+	_, _, _ = ds.ToSQL() // want "goqu dataset without Select\\(\\) renders SELECT \\*"
+
+	selected := goqu.From("users").Select("id")
+	selected = selected.Order("id")
+	_, _, _ = selected.ToSQL()
+
+	// Datasets are immutable, a discarded Select leaves the dataset unchanged
+	unchanged := goqu.From("users")
+	unchanged.Select("id")
+	_, _, _ = unchanged.ToSQL() // want "goqu dataset without Select\\(\\) renders SELECT \\*"
+}
+
+// Datasets received as parameters are reported where the caller passes them
+func render(ds *goqu.SelectDataset) string { // want render:"builder returns=false escapes=\\[0\\] forwards=\\[\\] completes=\\[\\]"
+	sql, _, _ := ds.ToSQL()
+	return sql
+}
+
+func helpers() {
+	_ = render(goqu.From("users").Select("id"))
+	_ = render(goqu.From("users")) // want "goqu dataset without Select\\(\\) renders SELECT \\*"
+}
+
+// Exported functions may get datasets from anywhere and report them at use
+func Render(ds *goqu.SelectDataset) string {
+	sql, _, _ := ds.ToSQL() // want "goqu dataset without Select\\(\\) renders SELECT \\*"
+	return sql
+}
+
+func renderColumns(ds *goqu.SelectDataset) string {
+	sql, _, _ := ds.Select("id").ToSQL()
+	return sql
+}
@@ -0,0 +1,72 @@
+// Package gorm is a minimal stub of gorm.io/gorm for tests
+package gorm
+
+import "database/sql"
+
+// DB is a GORM database handle and query chain
+type DB struct{}
+
+// Session configures a new session
+type Session struct {
+	QueryFields bool
+}
+
+// Session starts a new session with the given configuration
+func (db *DB) Session(config *Session) *DB { return db }
+
+// WithContext sets the context of the query
+func (db *DB) WithContext(ctx any) *DB { return db }
+
+// Model sets the model of the query
+func (db *DB) Model(value any) *DB { return db }
+
+// Table sets the table of the query
+func (db *DB) Table(name string, args ...any) *DB { return db }
+
+// Where adds conditions
+func (db *DB) Where(query any, args ...any) *DB { return db }
+
+// Order sets the order
+func (db *DB) Order(value any) *DB { return db }
+
+// Select sets the selected columns
+func (db *DB) Select(query any, args ...any) *DB { return db }
+
+// Omit excludes columns
+func (db *DB) Omit(columns ...string) *DB { return db }
+
+// Distinct selects distinct values
+func (db *DB) Distinct(args ...any) *DB { return db }
+
+// Scopes applies scopes
+func (db *DB) Scopes(funcs ...func(*DB) *DB) *DB { return db }
+
+// Raw sets a raw SQL query
+func (db *DB) Raw(sql string, values ...any) *DB { return db }
+
+// Exec runs a raw SQL statement
+func (db *DB) Exec(sql string, values ...any) *DB { return db }
+
+// Find finds the records matching the conditions
+func (db *DB) Find(dest any, conds ...any) *DB { return db }
+
+// First finds the first record ordered by primary key
+func (db *DB) First(dest any, conds ...any) *DB { return db }
+
+// Take finds a record without ordering
+func (db *DB) Take(dest any, conds ...any) *DB { return db }
+
+// Last finds the last record ordered by primary key
+func (db *DB) Last(dest any, conds ...any) *DB { return db }
+
+// Scan scans the results into dest
+func (db *DB) Scan(dest any) *DB { return db }
+
+// Rows returns the result rows
+func (db *DB) Rows() (*sql.Rows, error) { return nil, nil }
+
+// Pluck queries a single column
+func (db *DB) Pluck(column string, dest any) *DB { return db }
+
+// Count counts the records
+func (db *DB) Count(count *int64) *DB { return db }
@@ -0,0 +1,134 @@
+// Package gormchains contains GORM query chains
+package gormchains
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// User is a GORM model
+type User struct {
+	ID   int
+	Name string
+}
+
+// Repo holds the database handle its methods query
+type Repo struct {
+	db *gorm.DB
+}
+
+// Finders without a projection select every column
+func (r *Repo) finders(ctx context.Context) {
+	var users []User
+	var user User
+
+	r.db.Where("active = ?", true).Find(&users) // want "GORM query without Select\\(\\) loads every column"
+	r.db.First(&user, 1)                        // want "GORM query without Select\\(\\) loads every column"
+	r.db.WithContext(ctx).Take(&user)           // want "GORM query without Select\\(\\) loads every column"
+	r.db.Model(&User{}).Last(&user)             // want "GORM query without Select\\(\\) loads every column"
+
+	var names []string
+	r.db.Table("users").Scan(&names) // want "GORM query without Select\\(\\) loads every column"
+
+	rows, _ := r.db.Table("users").Rows() // want "GORM query without Select\\(\\) loads every column"
+	_ = rows
+}
+
+// Select("*") is reported on its own
+func (r *Repo) selectStar() {
+	var users []User
+	r.db.Select("*").Find(&users) // want "avoid SELECT \\* in SQL builder"
+}
+
+// Raw queries are checked as SQL
+func (r *Repo) raw() {
+	var users []User
+	r.db.Raw("SELECT * FROM users").Scan(&users) // want "avoid SELECT \\* - explicitly specify needed columns"
+	r.db.Raw("SELECT id, name FROM users").Scan(&users)
+}
+
+// Chains with a projection are not reported
+func (r *Repo) projections() {
+	var users []User
+	var user User
+
+	r.db.Select("id", "name").Where("active = ?", true).Find(&users)
+	r.db.Where("active = ?", true).Select([]string{"id", "name"}).First(&user)
+	r.db.Omit("password_hash").Find(&users)
+	r.db.Distinct("name").Find(&users)
+	r.db.Session(&gorm.Session{QueryFields: true}).Find(&users)
+	r.db.Scopes(activeOnly).Find(&users)
+
+	var names []string
+	r.db.Model(&User{}).Pluck("name", &names)
+
+	var count int64
+	r.db.Model(&User{}).Count(&count)
+}
+
+func activeOnly(db *gorm.DB) *gorm.DB {
+	return db.Select("id", "name").Where("active = ?", true)
+}
+
+// Chains are followed through local variables
+func (r *Repo) variables(admin bool) {
+	var users []User
+
+	query := r.db.Where("active = ?", true)
+	if admin {
+		query = query.Where("admin = ?", true)
+	}
+	query.Find(&users) // want "GORM query without Select\\(\\) loads every column"
+
+	selected := r.db.Select("id", "name")
+	selected = selected.Order("name")
+	selected.Find(&users)
+
+	mutated := r.db.Where("active = ?", true)
+	mutated.Select("id")
+	mutated.Find(&users)
+
+	// Methods called on the handle itself start a new chain
+	db := r.db
+	db.Select("id").Find(&users)
+	db.Find(&users) // want "GORM query without Select\\(\\) loads every column"
+}
+
+// Handles received as parameters are reported at their finders
+func ListUsers(db *gorm.DB) { // want ListUsers:"builder returns=false escapes=\\[0\\] forwards=\\[\\] completes=\\[\\]"
+	var users []User
+	var user User
+	db.Where("a = ?", 1).Find(&users) // want "GORM query without Select\\(\\) loads every column"
+	db.First(&user, 1)                // want "GORM query without Select\\(\\) loads every column"
+	db.Select("id").Find(&users)
+
+	// Passing the handle on is left to the helper
+	list(db)
+}
+
+func list(db *gorm.DB) { // want list:"builder returns=false escapes=\\[0\\] forwards=\\[\\] completes=\\[\\]"
+	var users []User
+	db.Find(&users) // want "GORM query without Select\\(\\) loads every column"
+}
+
+func active(db *gorm.DB) *gorm.DB { // want active:"builder returns=false escapes=\\[\\] forwards=\\[0\\] completes=\\[\\]"
+	return db.Where("active = ?", true)
+}
+
+func (r *Repo) base() *gorm.DB { // want base:"builder returns=true escapes=\\[\\] forwards=\\[\\] completes=\\[\\]"
+	return r.db.Where("deleted_at IS NULL")
+}
+
+// Chains carrying conditions are also reported where the caller passes them
+func (r *Repo) helpers() {
+	list(r.db)
+	list(r.db.Select("id"))
+	list(active(r.db.Select("id")))
+	list(r.db.Where("admin = ?", true)) // want "GORM query without Select\\(\\) loads every column"
+	list(active(r.db))                  // want "GORM query without Select\\(\\) loads every column"
+
+	var users []User
+	r.base().Select("id").Find(&users)
+	r.base().Find(&users) // want "GORM query without Select\\(\\) loads every column"
+}
@@ -0,0 +1,41 @@
+// Package ignored contains calls to functions excluded by the ignored-functions setting
+package ignored
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// Logger is an in-house logger whose messages are not queries
+type Logger struct{}
+
+// Debug logs a message
+func (l *Logger) Debug(msg string) {}
+
+// Info logs a message
+func (l *Logger) Info(msg string) {}
+
+// Arguments of ignored functions and methods are never reported
+func ignoredCalls(db *sql.DB, logger *Logger, table string) {
+	fmt.Printf("SELECT * FROM debug_table")
+	log.Printf("Executing: SELECT * FROM logs")
+	log.Println("SELECT * FROM audit")
+	logger.Debug("SELECT * FROM sessions")
+
+	rows, _ := db.Query("SELECT * FROM users")
+	_ = rows
+
+	query := "SELECT * FROM " + table
+	log.Print(query)
+}
+
+// Functions that merely share a name with an ignored one are still checked
+func checkedCalls(db *sql.DB, logger *Logger) {
+	row := db.QueryRow("SELECT * FROM users WHERE id = 1") // want "avoid SELECT \\* - explicitly specify needed columns"
+	_ = row
+
+	logger.Info("SELECT * FROM sessions") // want "avoid SELECT \\* - explicitly specify needed columns"
+
+	_ = fmt.Sprintf("SELECT * FROM %s", "orders") // want "avoid SELECT \\* - explicitly specify needed columns"
+}
@@ -0,0 +1,14 @@
+// Package inserts contains INSERT statements without a target column list
+package inserts
+
+import "database/sql"
+
+func positionalInserts(db *sql.DB) {
+	_, _ = db.Exec("INSERT INTO users VALUES ($1, $2)", 1, "john")         // want "avoid INSERT without column list"
+	_, _ = db.Exec("INSERT INTO users_copy SELECT * FROM users")           // want "avoid INSERT without column list" "avoid INSERT ... SELECT \\* - columns are copied by position"
+	_, _ = db.Exec("INSERT INTO users (id, name) SELECT * FROM old_users") // want "avoid INSERT ... SELECT \\* - columns are copied by position"
+
+	explicit := "INSERT INTO users (id, name) VALUES ($1, $2)"
+	set := "INSERT INTO users SET id = 1"
+	_, _ = explicit, set
+}
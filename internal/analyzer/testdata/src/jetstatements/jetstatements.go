@@ -0,0 +1,29 @@
+// Package jetstatements contains go-jet SELECT statements
+package jetstatements
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+// Users stands for a table generated by jet
+var Users struct {
+	postgres.Table
+	ID, Name   postgres.Expression
+	AllColumns postgres.ColumnList
+}
+
+// STAR projections are reported where they are selected
+func stars() {
+	_, _ = postgres.SELECT(postgres.STAR).FROM(Users).Sql() // want "avoid SELECT \\* in SQL builder"
+	_, _ = Users.SELECT(Users.ID, postgres.STAR).Sql()      // want "avoid SELECT \\* in SQL builder"
+	_, _ = SELECT(STAR).FROM(Users).Sql()                   // want "avoid SELECT \\* in SQL builder"
+}
+
+// Explicit columns and COUNT(*) are not reported
+func projected() {
+	_, _ = postgres.SELECT(Users.ID, Users.Name).FROM(Users).Sql()
+	_, _ = postgres.SELECT(Users.AllColumns).FROM(Users).Sql()
+	_, _ = Users.SELECT(postgres.COUNT(postgres.STAR)).Sql()
+	_, _ = SELECT(String("*")).FROM(Users).Sql()
+}
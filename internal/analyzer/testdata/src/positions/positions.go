@@ -0,0 +1,23 @@
+// Package positions contains queries whose diagnostics must point at the star itself
+package positions
+
+// Stars in different parts of a query
+func queryContexts() {
+	top := "SELECT * FROM users"                                                   // want "avoid SELECT \\* - explicitly specify needed columns"
+	subquery := "SELECT id FROM users WHERE id IN (SELECT * FROM admins)"          // want "avoid SELECT \\* in subquery - can cause performance issues"
+	cte := "WITH a AS (SELECT * FROM admins) SELECT id FROM a"                     // want "avoid SELECT \\* in CTE - every column is carried through the query"
+	derived := "SELECT t.id FROM (SELECT * FROM users) t"                          // want "avoid SELECT \\* in derived table - explicitly specify columns"
+	union := "SELECT id FROM users UNION ALL SELECT * FROM admins"                 // want "avoid SELECT \\* in UNION branch - column count and order must match"
+	joined := "SELECT u.id FROM users u JOIN (SELECT o.* FROM orders o) x ON true" // want "avoid SELECT o\\.\\* in derived table"
+	_, _, _, _, _, _ = top, subquery, cte, derived, union, joined
+}
+
+// Stars after escape sequences and on later lines of raw strings
+func literalForms() {
+	escaped := "SELECT \"id\" FROM t\tWHERE x IN (SELECT * FROM y)" // want "avoid SELECT \\* in subquery"
+	unicode := "SELECT 'héllo' AS greeting, * FROM t"               // want "avoid SELECT \\* - explicitly specify needed columns"
+	raw := `SELECT id
+		FROM users
+		WHERE id IN (SELECT * FROM admins)` // want "avoid SELECT \\* in subquery"
+	_, _, _ = escaped, unicode, raw
+}
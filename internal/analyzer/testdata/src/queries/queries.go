@@ -0,0 +1,25 @@
+// Package queries keeps SQL queries shared by other test packages
+package queries
+
+// AllUsers selects every column of the users table
+const AllUsers = "SELECT * FROM users"
+
+// UserColumns is the explicit projection of the users table
+const UserColumns = "id, name, email"
+
+// Star is a projection wildcard used to build queries
+const Star = "*"
+
+// ActiveUsers selects every column of the active users
+var ActiveUsers = "SELECT * FROM users WHERE active"
+
+// SortedUsers is built from another variable
+var SortedUsers = ActiveUsers + " ORDER BY id"
+
+// Current is replaced at run time and cannot be followed
+var Current = "SELECT * FROM users"
+
+// UseAdmins switches Current to another query
+func UseAdmins() {
+	Current = "SELECT id FROM admins"
+}
@@ -0,0 +1,98 @@
+// Package scandest contains queries whose stars are expanded from the struct the rows are scanned into
+package scandest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+	"github.com/jmoiron/sqlx"
+	"github.com/uptrace/bun"
+	"gorm.io/gorm"
+)
+
+type User struct {
+	ID        int64  `db:"id"`
+	Name      string `db:"name"`
+	Email     sql.NullString
+	Password  string    `db:"-"`
+	CreatedAt time.Time `db:"created_at"`
+	internal  bool
+}
+
+type Timestamps struct {
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type Order struct {
+	ID     uint
+	UserID uint `gorm:"column:buyer_id;index"`
+	Total  float64
+	Notes  string `gorm:"-"`
+	Timestamps
+}
+
+type Account struct {
+	bun.BaseModel `bun:"table:accounts"`
+
+	ID      int64  `bun:",pk"`
+	Holder  string `bun:"holder_name"`
+	Owner   *User  `bun:"rel:belongs-to"`
+	Balance int64
+}
+
+type Profile struct {
+	User
+	Tags []string
+}
+
+// sqlx maps columns with the db tag and lower-cases untagged fields
+func sqlxQueries(ctx context.Context, db *sqlx.DB) {
+	var users []User
+	_ = db.Select(&users, "SELECT * FROM users WHERE active") // want "avoid SELECT \\* - explicitly specify needed columns"
+
+	var user User
+	_ = db.Get(&user, "SELECT u.* FROM users u JOIN orders o ON o.user_id = u.id") // want "avoid SELECT u.\\* - explicitly specify needed columns"
+	_ = db.QueryRowx("SELECT * FROM users WHERE id = $1", 1).StructScan(&user)     // want "avoid SELECT \\* - explicitly specify needed columns"
+	_ = sqlx.Select(db, &users, "SELECT * FROM users")                             // want "avoid SELECT \\* - explicitly specify needed columns"
+
+	ptrs := []*User{}
+	_ = db.SelectContext(ctx, &ptrs, "SELECT * FROM users") // want "avoid SELECT \\* - explicitly specify needed columns"
+}
+
+// GORM uses the column setting of the gorm tag and snake case names
+func gormQueries(db *gorm.DB) {
+	var orders []Order
+	db.Raw("SELECT * FROM orders").Scan(&orders) // want "avoid SELECT \\* - explicitly specify needed columns"
+}
+
+// bun uses the name of the bun tag and skips relations
+func bunQueries(ctx context.Context, db *bun.DB) {
+	var accounts []Account
+	_ = db.NewRaw("SELECT * FROM accounts").Scan(ctx, &accounts) // want "avoid SELECT \\* - explicitly specify needed columns"
+}
+
+// scany names untagged fields in snake case
+func scanyQueries(ctx context.Context, db *sql.DB) {
+	var users []User
+	_ = sqlscan.Select(ctx, db, &users, "SELECT * FROM users") // want "avoid SELECT \\* - explicitly specify needed columns"
+}
+
+// Stars whose columns are not those of the destination keep the diagnostic without a fix
+func unresolved(db *sqlx.DB) {
+	var users []User
+	_ = db.Select(&users, "SELECT * FROM users u JOIN orders o ON o.user_id = u.id") // want "avoid SELECT \\* - explicitly specify needed columns"
+	_ = db.Select(&users, "SELECT *, 1 AS one FROM users")                           // want "avoid SELECT \\* - explicitly specify needed columns"
+	_ = db.Select(&users, "SELECT * FROM users UNION SELECT * FROM admins")          // want "avoid SELECT \\* - explicitly specify needed columns" "avoid SELECT \\* in UNION branch"
+
+	var profiles []Profile
+	_ = db.Select(&profiles, "SELECT * FROM profiles") // want "avoid SELECT \\* - explicitly specify needed columns"
+
+	var count int
+	_ = db.Get(&count, "SELECT * FROM counters") // want "avoid SELECT \\* - explicitly specify needed columns"
+
+	rows, _ := db.Queryx("SELECT * FROM users") // want "avoid SELECT \\* - explicitly specify needed columns"
+	_ = rows
+}
@@ -0,0 +1,91 @@
+// Package scans contains SELECT * queries whose rows are scanned by position
+package scans
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Rows of SELECT * scanned by position are reported at the Scan call
+func positional(db *sql.DB, table string) {
+	rows, _ := db.Query("SELECT * FROM accounts") // want "avoid SELECT \\* - explicitly specify needed columns"
+	defer rows.Close()
+	for rows.Next() {
+		var id, name string
+		_ = rows.Scan(&id, &name) // want "SELECT \\* rows scanned by position"
+	}
+
+	query := "SELECT * FROM " + table // want "avoid SELECT \\* - explicitly specify needed columns"
+	var id int
+	_ = db.QueryRow(query, 1).Scan(&id) // want "SELECT \\* rows scanned by position"
+}
+
+// With a schema, the destinations are counted against the columns
+func counted(ctx context.Context, db *sql.DB, conn *pgx.Conn) {
+	var id, userID int
+	var name, email string
+	var total float64
+
+	_ = db.QueryRow("SELECT * FROM users WHERE id = $1", 1).Scan(&id, &name)                                       // want "avoid SELECT \\* - explicitly specify needed columns" "Scan has 2 destinations but SELECT \\* returns 3 columns of users"
+	_ = db.QueryRowContext(ctx, "SELECT * FROM users").Scan(&id, &name, &email)                                    // want "avoid SELECT \\* - explicitly specify needed columns" "SELECT \\* rows scanned by position"
+	_ = db.QueryRow("SELECT u.*, o.total FROM users u JOIN orders o ON o.user_id = u.id").Scan(&id, &name, &total) // want "avoid SELECT u.\\* - explicitly specify needed columns" "Scan has 3 destinations but SELECT \\* returns 4 columns of users"
+
+	rows, _ := conn.Query(ctx, "SELECT * FROM orders") // want "avoid SELECT \\* - explicitly specify needed columns"
+	for rows.Next() {
+		_ = rows.Scan(&id, &userID) // want "Scan has 2 destinations but SELECT \\* returns 3 columns of orders"
+	}
+}
+
+// Rows held in a variable captured by a closure are followed
+func captured(db *sql.DB) {
+	rows, err := db.Query("SELECT * FROM users") // want "avoid SELECT \\* - explicitly specify needed columns"
+	if err != nil {
+		return
+	}
+	defer func() { _ = rows.Close() }()
+	var id int
+	_ = rows.Scan(&id) // want "Scan has 1 destination but SELECT \\* returns 3 columns of users"
+}
+
+// Explicit columns, subquery stars and other scans are not reported
+func explicit(db *sql.DB, dest []any) {
+	var id int
+	_ = db.QueryRow("SELECT id FROM users").Scan(&id)
+	_ = db.QueryRow("SELECT id FROM users WHERE id IN (SELECT * FROM orders)").Scan(&id) // want "avoid SELECT \\* in subquery"
+	_ = db.QueryRow("SELECT * FROM users").Scan(dest...)                                 // want "avoid SELECT \\* - explicitly specify needed columns" "SELECT \\* rows scanned by position"
+	_ = db.QueryRow("SELECT count(*) FROM users").Scan(&id)
+}
+
+// A query assigned in several places is reported at its literals, and its
+// Scan at the call reading the rows
+func branches(db *sql.DB, active bool) {
+	var query string
+	if active {
+		query = "SELECT * FROM accounts" // want "avoid SELECT \\* - explicitly specify needed columns"
+	} else {
+		query = "SELECT * FROM accounts" // want "avoid SELECT \\* - explicitly specify needed columns"
+	}
+	var id int
+	_ = db.QueryRow(query).Scan(&id) // want "SELECT \\* rows scanned by position"
+
+	counted := "SELECT * FROM users WHERE id = 1" // want "avoid SELECT \\* - explicitly specify needed columns"
+	if active {
+		counted = "SELECT * FROM users WHERE id = 1" // want "avoid SELECT \\* - explicitly specify needed columns"
+	}
+	_ = db.QueryRow(counted).Scan(&id) // want "Scan has 1 destination but SELECT \\* returns 3 columns of users"
+}
+
+// Queries written in exclusive branches are followed path by path
+func builderBranches(db *sql.DB, admin bool) {
+	var sb strings.Builder
+	if admin {
+		sb.WriteString("SELECT id FROM admins -- admins only")
+	} else {
+		sb.WriteString("SELECT * FROM users WHERE id = 1") // want "avoid SELECT \\* - explicitly specify needed columns"
+	}
+	var id int
+	_ = db.QueryRow(sb.String()).Scan(&id) // want "Scan has 1 destination but SELECT \\* returns 3 columns of users"
+}
@@ -0,0 +1,43 @@
+// Package app selects sensitive columns outside the allowed packages
+package app
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Stars over tables with sensitive columns are reported as sensitive data
+func stars(db *sql.DB) {
+	_, _ = db.Query("SELECT * FROM users")                                        // want `sensitive data: SELECT \* exposes column users.password_hash - explicitly specify the needed columns`
+	_, _ = db.Query("SELECT o.*, p.* FROM orders o JOIN people p ON p.id = o.id") // want `avoid SELECT o.\* - explicitly specify needed columns` `sensitive data: SELECT p.\* exposes column people.ssn`
+	_, _ = db.Query("SELECT * FROM audit_log")                                    // want `sensitive data: SELECT \* exposes column audit_log.\*`
+	_, _ = db.Query("SELECT * FROM orders")                                       // want `avoid SELECT \* - explicitly specify needed columns`
+	_, _ = db.Query("SELECT id FROM orders WHERE EXISTS (SELECT * FROM users)")
+}
+
+// RETURNING and OUTPUT stars of statements modifying such tables are reported as well
+func returning(db *sql.DB) {
+	_, _ = db.Query("DELETE FROM users WHERE id = $1 RETURNING *", 1)          // want `sensitive data: RETURNING \* exposes column users.password_hash`
+	_, _ = db.Query("UPDATE people p SET name = $1 RETURNING p.*", "x")        // want `sensitive data: RETURNING p.\* exposes column people.ssn`
+	_, _ = db.Query("INSERT INTO users OUTPUT inserted.* VALUES (@name)", "x") // want `sensitive data: OUTPUT inserted.\* exposes column users.password_hash`
+	_, _ = db.Query("INSERT INTO orders (id) VALUES (1) RETURNING *")          // want `avoid RETURNING \* - explicitly specify returned columns`
+}
+
+// Sensitive columns named in projections are reported
+func columns(db *sql.DB) {
+	_, _ = db.Query("SELECT id, password_hash FROM users")                       // want `sensitive data: column users.password_hash is selected outside the allowed packages`
+	_, _ = db.Query("SELECT u.id, p.ssn AS number FROM users u, people p")       // want `sensitive data: column people.ssn is selected outside the allowed packages`
+	_, _ = db.Query("SELECT name, ssn FROM orders JOIN people ON people.id = 1") // want `sensitive data: column people.ssn is selected outside the allowed packages`
+	_, _ = db.Query("SELECT id, name, email FROM users")
+	_, _ = db.Query("SELECT id FROM users WHERE password_hash = $1", "x")
+}
+
+// Builder columns are checked against the tables of the chain
+func builders() {
+	_, _, _ = sq.Select("id", "password_hash").From("users").ToSql() // want `sensitive data: column users.password_hash is selected outside the allowed packages`
+	_, _, _ = sq.Select("*").From("people").ToSql()                  // want `sensitive data: SELECT \* exposes column people.ssn`
+	_, _, _ = sq.Select().Columns("*").From("users").ToSql()         // want `sensitive data: SELECT \* exposes column users.password_hash`
+	_, _, _ = sq.Select("*").From("orders").ToSql()                  // want `avoid SELECT \* in SQL builder`
+	_, _, _ = sq.Select("id", "name").From("users").ToSql()
+}
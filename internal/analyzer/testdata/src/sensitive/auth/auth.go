@@ -0,0 +1,10 @@
+// Package auth may select sensitive columns by name
+package auth
+
+import "database/sql"
+
+// Sensitive columns are selected by name, but stars are still reported
+func login(db *sql.DB) {
+	_, _ = db.Query("SELECT id, password_hash FROM users WHERE email = $1", "a@example.com")
+	_, _ = db.Query("SELECT * FROM users WHERE email = $1", "a@example.com") // want `sensitive data: SELECT \* exposes column users.password_hash`
+}
@@ -0,0 +1,53 @@
+// Package sinks contains queries checked in strict mode, where only SQL reaching a sink is reported
+package sinks
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store runs queries through an in-house API registered with the sinks setting
+type Store struct{}
+
+// Run runs a query
+func (s *Store) Run(query string) error { return nil }
+
+// Queries reaching database/sql, sqlx and pgx are reported
+func knownSinks(ctx context.Context, db *sql.DB, xdb *sqlx.DB, conn *pgx.Conn, table string) {
+	rows, _ := db.QueryContext(ctx, "SELECT * FROM users") // want "avoid SELECT \\* - explicitly specify needed columns"
+	_ = rows
+
+	var users []struct{ ID int }
+	_ = xdb.Select(&users, "SELECT * FROM users") // want "avoid SELECT \\* - explicitly specify needed columns"
+
+	query := "SELECT * FROM " + table // want "avoid SELECT \\* - explicitly specify needed columns"
+	_, _ = conn.Query(ctx, query)
+
+	batch := &pgx.Batch{}
+	batch.Queue(fmt.Sprintf("SELECT %s FROM orders", "*")) // want "avoid SELECT \\* - explicitly specify needed columns"
+
+	// Methods promoted from an embedded *sql.DB are sinks too
+	_, _ = xdb.Exec("INSERT INTO audit SELECT * FROM events") // want "avoid INSERT ... SELECT \\* - columns are copied by position"
+}
+
+// Queries reaching a sink added in the settings are reported
+func customSinks(store *Store) {
+	_ = store.Run("SELECT * FROM sessions") // want "avoid SELECT \\* - explicitly specify needed columns"
+}
+
+// SQL that never reaches a sink is not reported
+func otherCalls(db *sql.DB) {
+	log.Printf("running SELECT * FROM users")
+	fmt.Println("SELECT * FROM debug")
+
+	unused := "SELECT * FROM users"
+	_ = unused
+
+	logged := "SELECT * FROM orders"
+	log.Print(logged)
+}
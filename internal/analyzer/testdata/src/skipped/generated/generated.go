@@ -0,0 +1,5 @@
+// Package generated is below an ignored package
+package generated
+
+// AllRows is generated code selecting every column
+const AllRows = "SELECT * FROM rows"
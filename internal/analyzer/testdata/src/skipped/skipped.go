@@ -0,0 +1,8 @@
+// Package skipped is excluded by the ignored-packages setting
+package skipped
+
+// Nothing in an ignored package is reported
+func queries() {
+	query := "SELECT * FROM anywhere"
+	_ = query
+}
@@ -0,0 +1,376 @@
+// Package sqllex provides a small, dialect-tolerant SQL tokenizer.
+//
+// The lexer does not validate SQL. It only splits query text into tokens
+// precisely enough for the analyzer to reason about projection lists: string
+// literals, quoted identifiers and comments are recognized so that their
+// contents are never mistaken for SQL keywords or operators.
+package sqllex
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kind identifies the lexical class of a token.
+type Kind int
+
+const (
+	// Ident is a bare identifier or keyword: users, SELECT, count
+	Ident Kind = iota + 1
+	// QuotedIdent is a delimited identifier: "users", `users`, [users]
+	QuotedIdent
+	// String is a string literal: 'abc', E'a\'b', N'abc', $$abc$$
+	String
+	// Number is a numeric literal: 42, 3.14, .5, 1e10
+	Number
+	// Param is a bind parameter or variable: ?, $1, :name, @name
+	Param
+	// Operator is any punctuation or operator: *, ., ",", (, ), <=, ::
+	Operator
+	// LineComment is a comment started with -- and ended by a newline
+	LineComment
+	// BlockComment is a /* ... */ comment, possibly nested
+	BlockComment
+	// Opaque stands for a part of the query that is unknown at analysis
+	// time, written as the Placeholder character
+	Opaque
+)
+
+// Placeholder is the character analyzers substitute for query text that is
+// unknown at analysis time, such as a value computed at run time. The lexer
+// returns each occurrence as a single Opaque token.
+const Placeholder = "\x1a"
+
+// String returns a human-readable name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case Ident:
+		return "Ident"
+	case QuotedIdent:
+		return "QuotedIdent"
+	case String:
+		return "String"
+	case Number:
+		return "Number"
+	case Param:
+		return "Param"
+	case Operator:
+		return "Operator"
+	case LineComment:
+		return "LineComment"
+	case BlockComment:
+		return "BlockComment"
+	case Opaque:
+		return "Opaque"
+	default:
+		return "Invalid"
+	}
+}
+
+// Token is a single lexical element of a query.
+type Token struct {
+	Kind Kind
+	// Text is the exact source text of the token, including quotes
+	Text string
+	// Pos is the byte offset of the token in the query
+	Pos int
+}
+
+// End returns the byte offset just past the token.
+func (t Token) End() int {
+	return t.Pos + len(t.Text)
+}
+
+// IsKeyword reports whether the token is the bare identifier kw (case-insensitive).
+func (t Token) IsKeyword(kw string) bool {
+	return t.Kind == Ident && strings.EqualFold(t.Text, kw)
+}
+
+// IsOperator reports whether the token is the operator op.
+func (t Token) IsOperator(op string) bool {
+	return t.Kind == Operator && t.Text == op
+}
+
+// IsComment reports whether the token is a line or block comment.
+func (t Token) IsComment() bool {
+	return t.Kind == LineComment || t.Kind == BlockComment
+}
+
+// Name returns the identifier the token denotes with quotes removed.
+// For tokens that are not identifiers it returns the token text.
+func (t Token) Name() string {
+	if t.Kind != QuotedIdent || len(t.Text) < 2 {
+		return t.Text
+	}
+	open, body := t.Text[0], t.Text[1:]
+	closing := open
+	if open == '[' {
+		closing = ']'
+	}
+	body = strings.TrimSuffix(body, string(closing))
+	return strings.ReplaceAll(body, string([]byte{closing, closing}), string(closing))
+}
+
+// multiCharOperators lists operators longer than one byte, longest first.
+var multiCharOperators = []string{
+	"->>", "<=>", "||", "::", "->", "<=", ">=", "<>", "!=", ":=", "=>", "<<", ">>",
+}
+
+// Lexer splits a query into tokens. The zero value is not usable; create
+// lexers with New.
+type Lexer struct {
+	src string
+	pos int
+}
+
+// New returns a lexer reading src.
+func New(src string) *Lexer {
+	return &Lexer{src: src}
+}
+
+// Next returns the next token, including comments. The second result is
+// false once the input is exhausted.
+func (l *Lexer) Next() (Token, bool) {
+	l.skipSpace()
+	if l.pos >= len(l.src) {
+		return Token{}, false
+	}
+
+	start := l.pos
+	kind := l.scan()
+	return Token{Kind: kind, Text: l.src[start:l.pos], Pos: start}, true
+}
+
+// Tokenize returns all significant tokens of src, dropping whitespace and comments.
+func Tokenize(src string) []Token {
+	var tokens []Token
+	l := New(src)
+	for {
+		tok, ok := l.Next()
+		if !ok {
+			return tokens
+		}
+		if !tok.IsComment() {
+			tokens = append(tokens, tok)
+		}
+	}
+}
+
+func (l *Lexer) skipSpace() {
+	for l.pos < len(l.src) {
+		r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+		if !unicode.IsSpace(r) {
+			return
+		}
+		l.pos += size
+	}
+}
+
+// scan consumes one token starting at l.pos and returns its kind.
+func (l *Lexer) scan() Kind {
+	c := l.src[l.pos]
+	switch {
+	case c == Placeholder[0]:
+		l.pos++
+		return Opaque
+	case c == '-' && l.peek(1) == '-':
+		l.skipUntil("\n")
+		return LineComment
+	case c == '/' && l.peek(1) == '*':
+		l.scanBlockComment()
+		return BlockComment
+	case c == '\'':
+		l.scanQuoted('\'', false)
+		return String
+	case (c == 'E' || c == 'e') && l.peek(1) == '\'':
+		l.pos++
+		l.scanQuoted('\'', true)
+		return String
+	case (c == 'N' || c == 'n' || c == 'B' || c == 'b' || c == 'X' || c == 'x') && l.peek(1) == '\'':
+		l.pos++
+		l.scanQuoted('\'', false)
+		return String
+	case c == '"':
+		l.scanQuoted('"', false)
+		return QuotedIdent
+	case c == '`':
+		l.scanQuoted('`', false)
+		return QuotedIdent
+	case c == '[' && l.isBracketIdent():
+		l.scanQuoted(']', false)
+		return QuotedIdent
+	case c == '$':
+		return l.scanDollar()
+	case c == '?':
+		l.pos++
+		return Param
+	case (c == ':' || c == '@') && l.peek(1) != c && isIdentStart(l.peekRune(1)):
+		l.pos++
+		l.scanIdentRest()
+		return Param
+	case c == '@' && l.peek(1) == '@':
+		l.pos += 2
+		l.scanIdentRest()
+		return Param
+	case isDigit(c) || (c == '.' && isDigit(l.peek(1))):
+		l.scanNumber()
+		return Number
+	}
+
+	if r, size := utf8.DecodeRuneInString(l.src[l.pos:]); isIdentStart(r) {
+		l.pos += size
+		l.scanIdentRest()
+		return Ident
+	}
+
+	for _, op := range multiCharOperators {
+		if strings.HasPrefix(l.src[l.pos:], op) {
+			l.pos += len(op)
+			return Operator
+		}
+	}
+	_, size := utf8.DecodeRuneInString(l.src[l.pos:])
+	l.pos += size
+	return Operator
+}
+
+func (l *Lexer) peek(n int) byte {
+	if l.pos+n < len(l.src) {
+		return l.src[l.pos+n]
+	}
+	return 0
+}
+
+func (l *Lexer) peekRune(n int) rune {
+	if l.pos+n >= len(l.src) {
+		return utf8.RuneError
+	}
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos+n:])
+	return r
+}
+
+// skipUntil advances to the next occurrence of s, or to the end of input.
+func (l *Lexer) skipUntil(s string) {
+	if idx := strings.Index(l.src[l.pos:], s); idx >= 0 {
+		l.pos += idx
+		return
+	}
+	l.pos = len(l.src)
+}
+
+// scanBlockComment consumes a block comment, honoring PostgreSQL-style nesting.
+func (l *Lexer) scanBlockComment() {
+	depth := 0
+	for l.pos < len(l.src) {
+		switch {
+		case l.src[l.pos] == '/' && l.peek(1) == '*':
+			depth++
+			l.pos += 2
+		case l.src[l.pos] == '*' && l.peek(1) == '/':
+			depth--
+			l.pos += 2
+			if depth == 0 {
+				return
+			}
+		default:
+			l.pos++
+		}
+	}
+}
+
+// scanQuoted consumes a delimited token whose opening delimiter is at l.pos.
+// A doubled closing delimiter is an escaped delimiter. With backslash set,
+// a backslash escapes the following byte as well.
+func (l *Lexer) scanQuoted(closing byte, backslash bool) {
+	l.pos++
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case backslash && c == '\\':
+			l.pos += 2
+		case c == closing && l.peek(1) == closing:
+			l.pos += 2
+		case c == closing:
+			l.pos++
+			return
+		default:
+			l.pos++
+		}
+	}
+	l.pos = len(l.src)
+}
+
+// isBracketIdent reports whether the '[' at l.pos opens a SQL Server style
+// bracketed identifier rather than an array subscript.
+func (l *Lexer) isBracketIdent() bool {
+	end := strings.IndexByte(l.src[l.pos:], ']')
+	if end < 2 {
+		return false
+	}
+	body := l.src[l.pos+1 : l.pos+end]
+	return !strings.ContainsAny(body, "[\n") && isIdentStart(l.peekRune(1))
+}
+
+// scanDollar consumes a positional parameter ($1) or a dollar-quoted string ($$...$$, $tag$...$tag$).
+func (l *Lexer) scanDollar() Kind {
+	if isDigit(l.peek(1)) {
+		l.pos++
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.pos++
+		}
+		return Param
+	}
+
+	end := l.pos + 1
+	for end < len(l.src) && isIdentByte(l.src[end]) {
+		end++
+	}
+	if end >= len(l.src) || l.src[end] != '$' {
+		l.pos++
+		return Operator
+	}
+
+	tag := l.src[l.pos : end+1]
+	l.pos = end + 1
+	l.skipUntil(tag)
+	l.pos = min(l.pos+len(tag), len(l.src))
+	return String
+}
+
+func (l *Lexer) scanIdentRest() {
+	for l.pos < len(l.src) {
+		r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+		if !isIdentStart(r) && !unicode.IsDigit(r) && r != '$' {
+			return
+		}
+		l.pos += size
+	}
+}
+
+func (l *Lexer) scanNumber() {
+	for l.pos < len(l.src) && (isDigit(l.src[l.pos]) || l.src[l.pos] == '.') {
+		l.pos++
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		next := l.peek(1)
+		if isDigit(next) || ((next == '+' || next == '-') && isDigit(l.peek(2))) {
+			l.pos += 2
+			for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+				l.pos++
+			}
+		}
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || isDigit(c) || (c|0x20 >= 'a' && c|0x20 <= 'z')
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
@@ -0,0 +1,180 @@
+package sqllex
+
+import (
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Token
+	}{
+		{
+			name:  "simple select",
+			input: "SELECT * FROM users",
+			expected: []Token{
+				{Kind: Ident, Text: "SELECT", Pos: 0},
+				{Kind: Operator, Text: "*", Pos: 7},
+				{Kind: Ident, Text: "FROM", Pos: 9},
+				{Kind: Ident, Text: "users", Pos: 14},
+			},
+		},
+		{
+			name:  "block comment between keyword and star",
+			input: "SELECT/**/*",
+			expected: []Token{
+				{Kind: Ident, Text: "SELECT", Pos: 0},
+				{Kind: Operator, Text: "*", Pos: 10},
+			},
+		},
+		{
+			name:  "line comment",
+			input: "SELECT id -- SELECT * FROM x\nFROM t",
+			expected: []Token{
+				{Kind: Ident, Text: "SELECT", Pos: 0},
+				{Kind: Ident, Text: "id", Pos: 7},
+				{Kind: Ident, Text: "FROM", Pos: 29},
+				{Kind: Ident, Text: "t", Pos: 34},
+			},
+		},
+		{
+			name:  "nested block comment",
+			input: "/* a /* b */ c */ x",
+			expected: []Token{
+				{Kind: Ident, Text: "x", Pos: 18},
+			},
+		},
+		{
+			name:  "string literal with escaped quote",
+			input: "'it''s SELECT *' x",
+			expected: []Token{
+				{Kind: String, Text: "'it''s SELECT *'", Pos: 0},
+				{Kind: Ident, Text: "x", Pos: 17},
+			},
+		},
+		{
+			name:  "escape string with backslash",
+			input: `E'a\'b' x`,
+			expected: []Token{
+				{Kind: String, Text: `E'a\'b'`, Pos: 0},
+				{Kind: Ident, Text: "x", Pos: 8},
+			},
+		},
+		{
+			name:  "dollar quoted string",
+			input: "$tag$SELECT * FROM t$tag$ $1",
+			expected: []Token{
+				{Kind: String, Text: "$tag$SELECT * FROM t$tag$", Pos: 0},
+				{Kind: Param, Text: "$1", Pos: 26},
+			},
+		},
+		{
+			name:  "quoted identifiers",
+			input: "\"my table\" `col` [dbo]",
+			expected: []Token{
+				{Kind: QuotedIdent, Text: "\"my table\"", Pos: 0},
+				{Kind: QuotedIdent, Text: "`col`", Pos: 11},
+				{Kind: QuotedIdent, Text: "[dbo]", Pos: 17},
+			},
+		},
+		{
+			name:  "numbers and parameters",
+			input: "3.14 .5 1e10 ? :name @p1",
+			expected: []Token{
+				{Kind: Number, Text: "3.14", Pos: 0},
+				{Kind: Number, Text: ".5", Pos: 5},
+				{Kind: Number, Text: "1e10", Pos: 8},
+				{Kind: Param, Text: "?", Pos: 13},
+				{Kind: Param, Text: ":name", Pos: 15},
+				{Kind: Param, Text: "@p1", Pos: 21},
+			},
+		},
+		{
+			name:  "operators",
+			input: "a::text<>b->>'k'",
+			expected: []Token{
+				{Kind: Ident, Text: "a", Pos: 0},
+				{Kind: Operator, Text: "::", Pos: 1},
+				{Kind: Ident, Text: "text", Pos: 3},
+				{Kind: Operator, Text: "<>", Pos: 7},
+				{Kind: Ident, Text: "b", Pos: 9},
+				{Kind: Operator, Text: "->>", Pos: 10},
+				{Kind: String, Text: "'k'", Pos: 13},
+			},
+		},
+		{
+			name:  "placeholder for unknown text",
+			input: "SELECT " + Placeholder + " FROM t",
+			expected: []Token{
+				{Kind: Ident, Text: "SELECT", Pos: 0},
+				{Kind: Opaque, Text: Placeholder, Pos: 7},
+				{Kind: Ident, Text: "FROM", Pos: 9},
+				{Kind: Ident, Text: "t", Pos: 14},
+			},
+		},
+		{
+			name:  "unterminated string",
+			input: "x 'abc",
+			expected: []Token{
+				{Kind: Ident, Text: "x", Pos: 0},
+				{Kind: String, Text: "'abc", Pos: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Tokenize(tt.input)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Tokenize(%q) = %v, want %v", tt.input, result, tt.expected)
+			}
+			for i := range result {
+				if result[i] != tt.expected[i] {
+					t.Errorf("Tokenize(%q)[%d] = %+v, want %+v", tt.input, i, result[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLexerComments(t *testing.T) {
+	l := New("-- header\nSELECT /* inline */ 1")
+
+	var kinds []Kind
+	for {
+		tok, ok := l.Next()
+		if !ok {
+			break
+		}
+		kinds = append(kinds, tok.Kind)
+	}
+
+	expected := []Kind{LineComment, Ident, BlockComment, Number}
+	if len(kinds) != len(expected) {
+		t.Fatalf("got kinds %v, want %v", kinds, expected)
+	}
+	for i := range kinds {
+		if kinds[i] != expected[i] {
+			t.Errorf("kind[%d] = %v, want %v", i, kinds[i], expected[i])
+		}
+	}
+}
+
+func TestTokenName(t *testing.T) {
+	tests := []struct {
+		token    Token
+		expected string
+	}{
+		{Token{Kind: Ident, Text: "users"}, "users"},
+		{Token{Kind: QuotedIdent, Text: `"my ""table"""`}, `my "table"`},
+		{Token{Kind: QuotedIdent, Text: "`users`"}, "users"},
+		{Token{Kind: QuotedIdent, Text: "[dbo]"}, "dbo"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.token.Name(); got != tt.expected {
+			t.Errorf("%q.Name() = %q, want %q", tt.token.Text, got, tt.expected)
+		}
+	}
+}
@@ -1,20 +1,112 @@
 // Package config provides configuration structures for Unqueryvet analyzer.
 package config
 
+import "github.com/MirrexOne/unqueryvet/pkg/schema"
+
 // UnqueryvetSettings holds the configuration for the Unqueryvet analyzer.
 type UnqueryvetSettings struct {
 	// CheckSQLBuilders enables checking SQL builders like Squirrel for SELECT * usage
 	CheckSQLBuilders bool `mapstructure:"check-sql-builders" json:"check-sql-builders" yaml:"check-sql-builders"`
 
+	// CheckQualifiedStars enables reporting table-qualified stars like u.* and "schema"."table".*
+	// in projections. Qualified RETURNING and OUTPUT stars are controlled by CheckReturning.
+	CheckQualifiedStars bool `mapstructure:"check-qualified-stars" json:"check-qualified-stars" yaml:"check-qualified-stars"`
+
+	// AllowStarInExists allows SELECT * inside EXISTS and NOT EXISTS subqueries,
+	// where the projection is never materialized
+	AllowStarInExists bool `mapstructure:"allow-star-in-exists" json:"allow-star-in-exists" yaml:"allow-star-in-exists"`
+
+	// CheckReturning enables reporting RETURNING * in INSERT, UPDATE and DELETE
+	// statements and SQL Server OUTPUT inserted.* / deleted.* clauses
+	CheckReturning bool `mapstructure:"check-returning" json:"check-returning" yaml:"check-returning"`
+
+	// CheckInsertColumnList enables reporting INSERT statements without an explicit
+	// target column list, like INSERT INTO t VALUES (...)
+	CheckInsertColumnList bool `mapstructure:"check-insert-column-list" json:"check-insert-column-list" yaml:"check-insert-column-list"`
+
+	// CheckInsertSelectStar enables reporting INSERT INTO t SELECT * FROM s copies,
+	// which get a dedicated message instead of the generic SELECT * warning
+	CheckInsertSelectStar bool `mapstructure:"check-insert-select-star" json:"check-insert-select-star" yaml:"check-insert-select-star"`
+
+	// CheckPositionalScan reports positional Scan calls of rows returned by a
+	// top-level SELECT *, like rows.Scan(&id, &name), which break when the
+	// table changes. With a schema, the number of destinations is also checked.
+	CheckPositionalScan bool `mapstructure:"check-positional-scan" json:"check-positional-scan" yaml:"check-positional-scan"`
+
+	// IgnoredFunctions lists functions and methods whose arguments are never checked.
+	// Names are fully qualified, like fmt.Printf, (*database/sql.DB).Query or
+	// github.com/rs/zerolog.Event.Msg, and may use * and ? wildcards.
+	IgnoredFunctions []string `mapstructure:"ignored-functions" json:"ignored-functions" yaml:"ignored-functions"`
+
+	// IgnoredPackages lists import paths of packages that are not analyzed.
+	// Patterns may use * and ? wildcards, and a trailing /... also matches
+	// every package below, like example.com/app/internal/gen/...
+	IgnoredPackages []string `mapstructure:"ignored-packages" json:"ignored-packages" yaml:"ignored-packages"`
+
+	// ExcludeFiles lists glob patterns of files that are not analyzed, matched
+	// against the file name and the trailing parts of its path, like *_test.go,
+	// *.pb.go or db/migrations/*.go. A pattern ending in a slash, like fixtures/,
+	// excludes every file below a directory of that name.
+	ExcludeFiles []string `mapstructure:"exclude-files" json:"exclude-files" yaml:"exclude-files"`
+
+	// Sinks lists functions and methods that execute or prepare SQL, in addition
+	// to the built-in database/sql, sqlx, pgx, GORM and bun sinks. Names use the
+	// format of IgnoredFunctions, like example.com/app/db.Querier.Run.
+	Sinks []string `mapstructure:"sinks" json:"sinks" yaml:"sinks"`
+
+	// StrictSinks only reports SQL that reaches a known sink, directly or through
+	// local variables, instead of every SQL string. SQL builders are still checked.
+	StrictSinks bool `mapstructure:"strict-sinks" json:"strict-sinks" yaml:"strict-sinks"`
+
+	// SQLBuilders lists import paths of in-house SQL builder packages checked like
+	// the built-in Squirrel, goqu, bun, go-jet and go-sqlbuilder support. Patterns
+	// use the format of IgnoredPackages, like example.com/app/internal/qb/...
+	SQLBuilders []string `mapstructure:"sql-builders" json:"sql-builders" yaml:"sql-builders"`
+
+	// Schema is the path of a schema source used to suggest fixes replacing
+	// SELECT * with the columns of the selected tables: a file of SQL DDL
+	// statements, a directory of .sql migrations, or a JSON catalog mapping
+	// table names to their columns. Relative paths in a configuration file
+	// are resolved against the directory of the file.
+	Schema string `mapstructure:"schema" json:"schema" yaml:"schema"`
+
+	// Catalog is the schema catalog, loaded from Schema once per run when it
+	// is nil. Programs embedding the analyzer may provide it directly.
+	Catalog *schema.Catalog `mapstructure:"-" json:"-" yaml:"-"`
+
+	// Sensitive marks columns holding sensitive data that queries must not
+	// select by accident.
+	Sensitive SensitiveSettings `mapstructure:"sensitive" json:"sensitive" yaml:"sensitive"`
+
 	// AllowedPatterns is a list of regex patterns that are allowed to use SELECT *
 	// Example: ["SELECT \\* FROM temp_.*", "SELECT \\* FROM .*_backup"]
 	AllowedPatterns []string `mapstructure:"allowed-patterns" json:"allowed-patterns" yaml:"allowed-patterns"`
 }
 
+// SensitiveSettings holds the sensitive column policy. SELECT * over a
+// table with a sensitive column is always reported, explicit projections of
+// sensitive columns only outside the allowed packages.
+type SensitiveSettings struct {
+	// Columns lists sensitive columns as table.column, like
+	// users.password_hash. Both parts may use * and ? wildcards, like *.ssn
+	// for an ssn column of any table or audit_log.* for every column of a
+	// table. The table may be schema-qualified, like public.users.ssn.
+	Columns []string `mapstructure:"columns" json:"columns" yaml:"columns"`
+
+	// AllowedPackages lists import paths of packages that may select
+	// sensitive columns by name, in the format of IgnoredPackages.
+	AllowedPackages []string `mapstructure:"allowed-packages" json:"allowed-packages" yaml:"allowed-packages"`
+}
+
 // DefaultSettings returns the default configuration for unqueryvet
 func DefaultSettings() UnqueryvetSettings {
 	return UnqueryvetSettings{
-		CheckSQLBuilders: true,
+		CheckSQLBuilders:      true,
+		CheckQualifiedStars:   true,
+		AllowStarInExists:     true,
+		CheckReturning:        true,
+		CheckInsertSelectStar: true,
+		CheckPositionalScan:   true,
 		AllowedPatterns: []string{
 			`(?i)COUNT\(\s*\*\s*\)`,
 			`(?i)MAX\(\s*\*\s*\)`,
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindFile(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(root, ".unqueryvet.json")
+	if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	found, ok := FindFile(nested)
+	if !ok || found != path {
+		t.Errorf("FindFile(%q) = %q, %v, want %q", nested, found, ok, path)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, ".unqueryvet.yml")
+	yamlData := "check-sql-builders: false\nschema: db/schema.sql\nallowed-patterns:\n  - \"temp_\"\nsensitive:\n  columns:\n    - \"*.ssn\"\n"
+	if err := os.WriteFile(yamlPath, []byte(yamlData), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	jsonPath := filepath.Join(dir, ".unqueryvet.json")
+	jsonData := `{"check-insert-column-list": true, "ignored-functions": ["fmt.Printf"]}`
+	if err := os.WriteFile(jsonPath, []byte(jsonData), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := LoadFile(yamlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.CheckSQLBuilders || !reflect.DeepEqual(settings.AllowedPatterns, []string{"temp_"}) {
+		t.Errorf("LoadFile(%q) = %+v", yamlPath, settings)
+	}
+	if !settings.CheckReturning {
+		t.Error("settings missing from the file should keep their defaults")
+	}
+	if !reflect.DeepEqual(settings.Sensitive.Columns, []string{"*.ssn"}) {
+		t.Errorf("Sensitive.Columns = %q, want [*.ssn]", settings.Sensitive.Columns)
+	}
+	if want := filepath.Join(dir, "db", "schema.sql"); settings.Schema != want {
+		t.Errorf("Schema = %q, want %q relative to the file", settings.Schema, want)
+	}
+
+	settings, err = LoadFile(jsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !settings.CheckInsertColumnList || !reflect.DeepEqual(settings.IgnoredFunctions, []string{"fmt.Printf"}) {
+		t.Errorf("LoadFile(%q) = %+v", jsonPath, settings)
+	}
+
+	badPath := filepath.Join(dir, "bad.yml")
+	if err := os.WriteFile(badPath, []byte("check-sql-builder: true\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(badPath); err == nil {
+		t.Error("LoadFile should reject unknown settings")
+	}
+}
+
+func TestApplyFlags(t *testing.T) {
+	flagged := DefaultSettings()
+	fs := flag.NewFlagSet("unqueryvet", flag.ContinueOnError)
+	flagged.RegisterFlags(fs)
+
+	// Drivers forward analyzer flags to their own flag set
+	forwarded := flag.NewFlagSet("driver", flag.ContinueOnError)
+	fs.VisitAll(func(f *flag.Flag) {
+		forwarded.Var(f.Value, f.Name, f.Usage)
+	})
+	args := []string{"-check-sql-builders=false", "-allowed-patterns=a", "-allowed-patterns=b", "-schema=schema.sql", "-sensitive.columns=users.password_hash"}
+	if err := forwarded.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+
+	settings := DefaultSettings()
+	settings.CheckReturning = false
+	settings.ApplyFlags(fs, &flagged)
+
+	if settings.CheckSQLBuilders {
+		t.Error("-check-sql-builders=false was not applied")
+	}
+	if !reflect.DeepEqual(settings.AllowedPatterns, []string{"a", "b"}) {
+		t.Errorf("AllowedPatterns = %q, want [a b]", settings.AllowedPatterns)
+	}
+	if settings.Schema != "schema.sql" {
+		t.Errorf("Schema = %q, want schema.sql", settings.Schema)
+	}
+	if !reflect.DeepEqual(settings.Sensitive.Columns, []string{"users.password_hash"}) {
+		t.Errorf("Sensitive.Columns = %q, want [users.password_hash]", settings.Sensitive.Columns)
+	}
+	if settings.CheckReturning {
+		t.Error("settings without flags should keep their value")
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileNames are the names of configuration files searched by FindFile, in order of preference.
+var FileNames = []string{".unqueryvet.yml", ".unqueryvet.yaml", ".unqueryvet.json"}
+
+// FindFile searches dir and its parent directories for a configuration file
+// and returns the path of the first one found.
+func FindFile(dir string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	for {
+		for _, name := range FileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// LoadFile reads settings from a YAML or JSON file. Settings missing from
+// the file keep their default values, unknown settings are an error. A
+// relative schema path is resolved against the directory of the file.
+func LoadFile(path string) (UnqueryvetSettings, error) {
+	settings := DefaultSettings()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return settings, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&settings)
+	case ".yml", ".yaml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&settings)
+	default:
+		return settings, fmt.Errorf("%s: unsupported configuration format, use .yml, .yaml or .json", path)
+	}
+	// An empty file leaves the defaults unchanged
+	if err != nil && !errors.Is(err, io.EOF) {
+		return settings, fmt.Errorf("%s: %w", path, err)
+	}
+	if settings.Schema != "" && !filepath.IsAbs(settings.Schema) {
+		settings.Schema = filepath.Join(filepath.Dir(path), settings.Schema)
+	}
+	return settings, nil
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+)
+
+// settingFlag describes the command-line flag of a single setting.
+type settingFlag struct {
+	name string
+	// register defines the flag on fs, bound to the setting of s
+	register func(fs *flag.FlagSet, s *UnqueryvetSettings)
+	// copy copies the setting from src to dst
+	copy func(dst, src *UnqueryvetSettings)
+}
+
+func boolSetting(name, usage string, field func(s *UnqueryvetSettings) *bool) settingFlag {
+	return settingFlag{
+		name: name,
+		register: func(fs *flag.FlagSet, s *UnqueryvetSettings) {
+			fs.Var(&boolValue{value: field(s)}, name, usage)
+		},
+		copy: func(dst, src *UnqueryvetSettings) {
+			*field(dst) = *field(src)
+		},
+	}
+}
+
+func listSetting(name, usage string, field func(s *UnqueryvetSettings) *[]string) settingFlag {
+	return settingFlag{
+		name: name,
+		register: func(fs *flag.FlagSet, s *UnqueryvetSettings) {
+			fs.Var(&listValue{list: field(s)}, name, usage+" (repeatable)")
+		},
+		copy: func(dst, src *UnqueryvetSettings) {
+			*field(dst) = append([]string(nil), *field(src)...)
+		},
+	}
+}
+
+func stringSetting(name, usage string, field func(s *UnqueryvetSettings) *string) settingFlag {
+	return settingFlag{
+		name: name,
+		register: func(fs *flag.FlagSet, s *UnqueryvetSettings) {
+			fs.Var(&stringValue{value: field(s)}, name, usage)
+		},
+		copy: func(dst, src *UnqueryvetSettings) {
+			*field(dst) = *field(src)
+		},
+	}
+}
+
+// settingFlags lists the flags of every setting, named like the keys of the configuration file.
+var settingFlags = []settingFlag{
+	boolSetting("check-sql-builders", "check SQL builders for SELECT *",
+		func(s *UnqueryvetSettings) *bool { return &s.CheckSQLBuilders }),
+	boolSetting("check-qualified-stars", "report table-qualified stars like u.*",
+		func(s *UnqueryvetSettings) *bool { return &s.CheckQualifiedStars }),
+	boolSetting("allow-star-in-exists", "allow SELECT * inside EXISTS subqueries",
+		func(s *UnqueryvetSettings) *bool { return &s.AllowStarInExists }),
+	boolSetting("check-returning", "report RETURNING * and OUTPUT inserted.*",
+		func(s *UnqueryvetSettings) *bool { return &s.CheckReturning }),
+	boolSetting("check-insert-column-list", "report INSERT statements without a column list",
+		func(s *UnqueryvetSettings) *bool { return &s.CheckInsertColumnList }),
+	boolSetting("check-insert-select-star", "report INSERT ... SELECT * copies",
+		func(s *UnqueryvetSettings) *bool { return &s.CheckInsertSelectStar }),
+	boolSetting("check-positional-scan", "report SELECT * rows scanned by position",
+		func(s *UnqueryvetSettings) *bool { return &s.CheckPositionalScan }),
+	listSetting("ignored-functions", "fully qualified function whose arguments are not checked",
+		func(s *UnqueryvetSettings) *[]string { return &s.IgnoredFunctions }),
+	listSetting("ignored-packages", "import path pattern of a package that is not analyzed",
+		func(s *UnqueryvetSettings) *[]string { return &s.IgnoredPackages }),
+	listSetting("exclude-files", "glob pattern of files that are not analyzed",
+		func(s *UnqueryvetSettings) *[]string { return &s.ExcludeFiles }),
+	listSetting("sinks", "fully qualified function that executes SQL, in addition to the built-in sinks",
+		func(s *UnqueryvetSettings) *[]string { return &s.Sinks }),
+	boolSetting("strict-sinks", "only report SQL reaching a known sink",
+		func(s *UnqueryvetSettings) *bool { return &s.StrictSinks }),
+	listSetting("sql-builders", "import path pattern of an in-house SQL builder package",
+		func(s *UnqueryvetSettings) *[]string { return &s.SQLBuilders }),
+	stringSetting("schema", "DDL file, migrations directory or JSON catalog used to suggest column lists",
+		func(s *UnqueryvetSettings) *string { return &s.Schema }),
+	listSetting("sensitive.columns", "sensitive column as table.column, like users.password_hash or *.ssn",
+		func(s *UnqueryvetSettings) *[]string { return &s.Sensitive.Columns }),
+	listSetting("sensitive.allowed-packages", "import path pattern of a package that may select sensitive columns",
+		func(s *UnqueryvetSettings) *[]string { return &s.Sensitive.AllowedPackages }),
+	listSetting("allowed-patterns", "regular expression of queries allowed to use SELECT *",
+		func(s *UnqueryvetSettings) *[]string { return &s.AllowedPatterns }),
+}
+
+// RegisterFlags registers a flag for every setting on fs, bound to the
+// settings of s. Flags are named like the keys of the configuration file.
+func (s *UnqueryvetSettings) RegisterFlags(fs *flag.FlagSet) {
+	for _, f := range settingFlags {
+		f.register(fs, s)
+	}
+}
+
+// ApplyFlags copies to s the settings of src whose flags, registered on fs
+// with src.RegisterFlags, were given on the command line, so that flags
+// override a configuration file. Flags set through a copy of the flag, as
+// drivers do when they forward analyzer flags, are detected as well.
+func (s *UnqueryvetSettings) ApplyFlags(fs *flag.FlagSet, src *UnqueryvetSettings) {
+	set := make(map[string]bool)
+	fs.VisitAll(func(f *flag.Flag) {
+		if v, ok := f.Value.(interface{ isSet() bool }); ok && v.isSet() {
+			set[f.Name] = true
+		}
+	})
+	for _, f := range settingFlags {
+		if set[f.name] {
+			f.copy(s, src)
+		}
+	}
+}
+
+// boolValue is a boolean flag that records whether it was set.
+type boolValue struct {
+	value *bool
+	set   bool
+}
+
+func (v *boolValue) String() string {
+	if v.value == nil {
+		return "false"
+	}
+	return strconv.FormatBool(*v.value)
+}
+
+func (v *boolValue) Set(value string) error {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	*v.value, v.set = b, true
+	return nil
+}
+
+func (v *boolValue) IsBoolFlag() bool { return true }
+
+func (v *boolValue) isSet() bool { return v.set }
+
+// stringValue is a string flag that records whether it was set.
+type stringValue struct {
+	value *string
+	set   bool
+}
+
+func (v *stringValue) String() string {
+	if v.value == nil {
+		return ""
+	}
+	return *v.value
+}
+
+func (v *stringValue) Set(value string) error {
+	*v.value, v.set = value, true
+	return nil
+}
+
+func (v *stringValue) isSet() bool { return v.set }
+
+// listValue is a repeatable flag collecting values into a list. The first
+// value given replaces the default list.
+type listValue struct {
+	list *[]string
+	set  bool
+}
+
+func (v *listValue) String() string {
+	if v.list == nil {
+		return ""
+	}
+	return strings.Join(*v.list, ",")
+}
+
+func (v *listValue) Set(value string) error {
+	if !v.set {
+		*v.list, v.set = nil, true
+	}
+	*v.list = append(*v.list, value)
+	return nil
+}
+
+func (v *listValue) isSet() bool { return v.set }
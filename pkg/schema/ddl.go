@@ -0,0 +1,352 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/MirrexOne/unqueryvet/internal/sqllex"
+)
+
+// tableModifiers are keywords that may appear between CREATE and TABLE.
+var tableModifiers = map[string]bool{
+	"GLOBAL":    true,
+	"LOCAL":     true,
+	"TEMP":      true,
+	"TEMPORARY": true,
+	"UNLOGGED":  true,
+}
+
+// constraintKeywords start a table constraint or option instead of a column
+// in the body of CREATE TABLE.
+var constraintKeywords = map[string]bool{
+	"CONSTRAINT": true,
+	"PRIMARY":    true,
+	"UNIQUE":     true,
+	"FOREIGN":    true,
+	"CHECK":      true,
+	"EXCLUDE":    true,
+	"KEY":        true,
+	"INDEX":      true,
+	"FULLTEXT":   true,
+	"SPATIAL":    true,
+	"LIKE":       true,
+	"PERIOD":     true,
+}
+
+// Apply applies the DDL statements of ddl to the catalog in order: CREATE
+// TABLE adds a table, ALTER TABLE adds, drops and renames columns or renames
+// the table, DROP TABLE and RENAME TABLE drop and rename tables. Other
+// statements and statements that cannot be parsed are ignored, as are
+// changes to tables missing from the catalog.
+func (c *Catalog) Apply(ddl string) {
+	for _, stmt := range statements(ddl) {
+		switch {
+		case len(stmt) < 2:
+		case stmt[0].IsKeyword("CREATE"):
+			table, ifNotExists, ok := parseCreateTable(stmt)
+			if _, exists := c.Table(table.Name); ok && !(exists && ifNotExists) {
+				c.Add(table)
+			}
+		case stmt[0].IsKeyword("ALTER") && stmt[1].IsKeyword("TABLE"):
+			c.alterTable(stmt)
+		case stmt[0].IsKeyword("DROP") && stmt[1].IsKeyword("TABLE"):
+			c.dropTables(stmt)
+		case stmt[0].IsKeyword("RENAME") && stmt[1].IsKeyword("TABLE"):
+			c.renameTables(stmt)
+		}
+	}
+}
+
+// alterTable applies ALTER TABLE [IF EXISTS] [ONLY] name action, ... where
+// each action adds, drops or renames a column or renames the table.
+func (c *Catalog) alterTable(stmt []sqllex.Token) {
+	i := skipKeywords(stmt, 2, "IF", "EXISTS")
+	i = skipKeywords(stmt, i, "ONLY")
+	name, i := qualifiedName(stmt, i)
+	table, ok := c.Table(name)
+	if !ok {
+		return
+	}
+	for _, action := range splitTopLevel(stmt[i:]) {
+		if len(action) == 0 {
+			continue
+		}
+		switch {
+		case action[0].IsKeyword("ADD"):
+			addColumn(table, action[1:])
+		case action[0].IsKeyword("DROP"):
+			dropColumn(table, action[1:])
+		case action[0].IsKeyword("RENAME") && len(action) >= 3 && action[1].IsKeyword("TO"):
+			newName, _ := qualifiedName(action, 2)
+			c.rename(table, newName)
+		case action[0].IsKeyword("RENAME"):
+			renameColumn(table, action[1:])
+		case action[0].IsKeyword("CHANGE"):
+			// MySQL: CHANGE [COLUMN] old new definition
+			j := skipKeywords(action, 1, "COLUMN")
+			if j+1 < len(action) && isIdent(action[j]) && isIdent(action[j+1]) {
+				table.replaceColumn(action[j].Name(), action[j+1])
+			}
+		}
+	}
+}
+
+// addColumn applies ADD [COLUMN] [IF NOT EXISTS] name definition, including
+// the MySQL FIRST and AFTER column placements. Constraints are ignored.
+func addColumn(table *Table, action []sqllex.Token) {
+	i := skipKeywords(action, 0, "COLUMN")
+	i = skipKeywords(action, i, "IF", "NOT", "EXISTS")
+	if i >= len(action) || !isIdent(action[i]) || isConstraint(action[i]) {
+		return
+	}
+	name := action[i].Name()
+	if table.columnIndex(name) >= 0 {
+		return
+	}
+
+	at := len(table.Columns)
+	for j := i + 1; j < len(action); j++ {
+		switch {
+		case action[j].IsKeyword("FIRST"):
+			at = 0
+		case action[j].IsKeyword("AFTER") && j+1 < len(action):
+			if k := table.columnIndex(action[j+1].Name()); k >= 0 {
+				at = k + 1
+			}
+		}
+	}
+	columns := make([]string, 0, len(table.Columns)+1)
+	columns = append(columns, table.Columns[:at]...)
+	columns = append(columns, name)
+	table.Columns = append(columns, table.Columns[at:]...)
+	table.markQuoted(action[i])
+}
+
+// dropColumn applies DROP [COLUMN] [IF EXISTS] name. Constraints are ignored.
+func dropColumn(table *Table, action []sqllex.Token) {
+	i := skipKeywords(action, 0, "COLUMN")
+	i = skipKeywords(action, i, "IF", "EXISTS")
+	if i >= len(action) || !isIdent(action[i]) || isConstraint(action[i]) {
+		return
+	}
+	if k := table.columnIndex(action[i].Name()); k >= 0 {
+		table.Columns = append(table.Columns[:k:k], table.Columns[k+1:]...)
+		delete(table.quoted, strings.ToLower(action[i].Name()))
+	}
+}
+
+// renameColumn applies RENAME [COLUMN] old TO new.
+func renameColumn(table *Table, action []sqllex.Token) {
+	i := skipKeywords(action, 0, "COLUMN")
+	if i+2 < len(action) && isIdent(action[i]) && !isConstraint(action[i]) && action[i+1].IsKeyword("TO") && isIdent(action[i+2]) {
+		table.replaceColumn(action[i].Name(), action[i+2])
+	}
+}
+
+// dropTables applies DROP TABLE [IF EXISTS] name, ...
+func (c *Catalog) dropTables(stmt []sqllex.Token) {
+	i := skipKeywords(stmt, 2, "IF", "EXISTS")
+	for _, item := range splitTopLevel(stmt[i:]) {
+		if name, _ := qualifiedName(item, 0); name != "" {
+			c.Remove(name)
+		}
+	}
+}
+
+// renameTables applies the MySQL RENAME TABLE old TO new, ...
+func (c *Catalog) renameTables(stmt []sqllex.Token) {
+	for _, item := range splitTopLevel(stmt[2:]) {
+		oldName, i := qualifiedName(item, 0)
+		if oldName == "" || i >= len(item) || !item[i].IsKeyword("TO") {
+			continue
+		}
+		newName, _ := qualifiedName(item, i+1)
+		if table, ok := c.Table(oldName); ok {
+			c.rename(table, newName)
+		}
+	}
+}
+
+// rename renames table to name.
+func (c *Catalog) rename(table *Table, name string) {
+	if name == "" {
+		return
+	}
+	c.Remove(table.Name)
+	table.Name = name
+	c.Add(table)
+}
+
+// columnIndex returns the index of the named column, compared
+// case-insensitively, or -1.
+func (t *Table) columnIndex(name string) int {
+	for i, column := range t.Columns {
+		if strings.EqualFold(column, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// replaceColumn renames the column old to the identifier name, keeping its
+// position.
+func (t *Table) replaceColumn(old string, name sqllex.Token) {
+	if i := t.columnIndex(old); i >= 0 {
+		columns := append([]string(nil), t.Columns...)
+		columns[i] = name.Name()
+		t.Columns = columns
+		delete(t.quoted, strings.ToLower(old))
+		t.markQuoted(name)
+	}
+}
+
+// markQuoted records whether the column declared by the identifier tok is quoted.
+func (t *Table) markQuoted(tok sqllex.Token) {
+	name := strings.ToLower(tok.Name())
+	if tok.Kind != sqllex.QuotedIdent {
+		delete(t.quoted, name)
+		return
+	}
+	if t.quoted == nil {
+		t.quoted = make(map[string]bool)
+	}
+	t.quoted[name] = true
+}
+
+// skipKeywords returns the index after the keywords starting at tokens[i]
+// when tokens[i:] starts with all of them, or i otherwise.
+func skipKeywords(tokens []sqllex.Token, i int, keywords ...string) int {
+	for j, keyword := range keywords {
+		if i+j >= len(tokens) || !tokens[i+j].IsKeyword(keyword) {
+			return i
+		}
+	}
+	return i + len(keywords)
+}
+
+// splitTopLevel splits tokens at commas outside of parentheses.
+func splitTopLevel(tokens []sqllex.Token) [][]sqllex.Token {
+	var (
+		parts [][]sqllex.Token
+		start int
+		depth int
+	)
+	for i, tok := range tokens {
+		switch {
+		case tok.IsOperator("("):
+			depth++
+		case tok.IsOperator(")"):
+			depth--
+		case depth == 0 && tok.IsOperator(","):
+			parts = append(parts, tokens[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, tokens[start:])
+}
+
+// isConstraint reports whether tok starts a constraint rather than a column.
+func isConstraint(tok sqllex.Token) bool {
+	return tok.Kind == sqllex.Ident && constraintKeywords[strings.ToUpper(tok.Text)]
+}
+
+// statements splits ddl into statements at semicolons.
+func statements(ddl string) [][]sqllex.Token {
+	var (
+		stmts [][]sqllex.Token
+		stmt  []sqllex.Token
+	)
+	for _, tok := range sqllex.Tokenize(ddl) {
+		if tok.IsOperator(";") {
+			if len(stmt) > 0 {
+				stmts = append(stmts, stmt)
+			}
+			stmt = nil
+			continue
+		}
+		stmt = append(stmt, tok)
+	}
+	if len(stmt) > 0 {
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
+
+// parseCreateTable parses CREATE TABLE [IF NOT EXISTS] name (definitions)
+// and reports whether the statement has IF NOT EXISTS.
+func parseCreateTable(stmt []sqllex.Token) (table *Table, ifNotExists, ok bool) {
+	table = new(Table)
+	i := 1
+	for i < len(stmt) && stmt[i].Kind == sqllex.Ident && tableModifiers[strings.ToUpper(stmt[i].Text)] {
+		i++
+	}
+	if i >= len(stmt) || !stmt[i].IsKeyword("TABLE") {
+		return table, false, false
+	}
+	j := skipKeywords(stmt, i+1, "IF", "NOT", "EXISTS")
+	ifNotExists = j > i+1
+
+	table.Name, j = qualifiedName(stmt, j)
+	if table.Name == "" || j >= len(stmt) || !stmt[j].IsOperator("(") {
+		return table, false, false
+	}
+	for _, column := range columnDefinitions(stmt, j) {
+		table.Columns = append(table.Columns, column.Name())
+		table.markQuoted(column)
+	}
+	return table, ifNotExists, len(table.Columns) > 0
+}
+
+// qualifiedName parses a possibly qualified name like t, s.t or "s"."t"
+// starting at tokens[i]. It returns the name without quotes and the index of
+// the first token after it, or an empty name if tokens[i] is not an identifier.
+func qualifiedName(tokens []sqllex.Token, i int) (string, int) {
+	var parts []string
+	for i < len(tokens) && isIdent(tokens[i]) {
+		parts = append(parts, tokens[i].Name())
+		if i+2 >= len(tokens) || !tokens[i+1].IsOperator(".") {
+			return strings.Join(parts, "."), i + 1
+		}
+		i += 2
+	}
+	return "", i
+}
+
+// columnDefinitions returns the identifiers of the columns defined in the
+// parenthesized list starting at tokens[i], skipping table constraints.
+func columnDefinitions(tokens []sqllex.Token, i int) []sqllex.Token {
+	var (
+		columns []sqllex.Token
+		depth   = 0
+		first   = true
+	)
+	for ; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch {
+		case tok.IsOperator("("):
+			depth++
+			if depth == 1 {
+				first = true
+				continue
+			}
+		case tok.IsOperator(")"):
+			depth--
+			if depth == 0 {
+				return columns
+			}
+		case depth == 1 && tok.IsOperator(","):
+			first = true
+			continue
+		}
+		if depth == 1 && first {
+			first = false
+			if isIdent(tok) && !isConstraint(tok) {
+				columns = append(columns, tok)
+			}
+		}
+	}
+	return columns
+}
+
+func isIdent(tok sqllex.Token) bool {
+	return tok.Kind == sqllex.Ident || tok.Kind == sqllex.QuotedIdent
+}
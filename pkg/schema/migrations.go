@@ -0,0 +1,142 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// migration is a migration file of a directory.
+type migration struct {
+	name string
+	// version is the leading number of the file name without leading
+	// zeros, or empty for files that are not versioned
+	version string
+}
+
+// LoadMigrations reads a catalog from a directory of .sql migrations and
+// applies them in order. It understands the layouts of the common migration
+// tools:
+//
+//   - golang-migrate: 1_create_users.up.sql and 1_create_users.down.sql,
+//     of which only the up migrations are applied
+//   - goose: 20240101120000_create_users.sql with -- +goose Up and
+//     -- +goose Down sections, of which only the up sections are applied
+//   - atlas: 20240101120000_init.sql next to an atlas.sum file
+//
+// Migrations are ordered by the version that starts their file name,
+// compared as a number, and files without a version come first in the order
+// of their names. Subdirectories and other files are ignored.
+func LoadMigrations(dir string) (*Catalog, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []migration
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(name), ".sql") || isDownMigration(name) {
+			continue
+		}
+		migrations = append(migrations, migration{name: name, version: migrationVersion(name)})
+	}
+	sort.SliceStable(migrations, func(i, j int) bool {
+		a, b := migrations[i], migrations[j]
+		if a.version != b.version {
+			return compareVersions(a.version, b.version) < 0
+		}
+		return a.name < b.name
+	})
+
+	catalog := NewCatalog()
+	for _, m := range migrations {
+		data, err := os.ReadFile(filepath.Join(dir, m.name))
+		if err != nil {
+			return nil, err
+		}
+		catalog.Apply(upMigration(string(data)))
+	}
+	return catalog, nil
+}
+
+// isDownMigration reports whether name is a golang-migrate down migration.
+func isDownMigration(name string) bool {
+	return strings.HasSuffix(strings.ToLower(name), ".down.sql")
+}
+
+// migrationVersion returns the digits starting name without leading zeros.
+func migrationVersion(name string) string {
+	end := 0
+	for end < len(name) && name[end] >= '0' && name[end] <= '9' {
+		end++
+	}
+	if end == 0 {
+		return ""
+	}
+	if version := strings.TrimLeft(name[:end], "0"); version != "" {
+		return version
+	}
+	return "0"
+}
+
+// compareVersions compares two versions as numbers of any size. Files
+// without a version sort first.
+func compareVersions(a, b string) int {
+	switch {
+	case len(a) != len(b):
+		return len(a) - len(b)
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// upMigration returns the statements of a migration that apply it. Files
+// with goose annotations (-- +goose Up, -- +goose Down) or dbmate
+// annotations (-- migrate:up, -- migrate:down) keep only their up sections;
+// other files are returned unchanged.
+func upMigration(text string) string {
+	var (
+		up        strings.Builder
+		annotated bool
+		inUp      bool
+	)
+	for _, line := range strings.SplitAfter(text, "\n") {
+		switch migrationDirection(line) {
+		case "up":
+			annotated, inUp = true, true
+			continue
+		case "down":
+			annotated, inUp = true, false
+			continue
+		}
+		if inUp {
+			up.WriteString(line)
+		}
+	}
+	if !annotated {
+		return text
+	}
+	return up.String()
+}
+
+// migrationDirection returns "up" or "down" when line is an annotation
+// starting the up or down section of a migration, or an empty string.
+func migrationDirection(line string) string {
+	comment, ok := strings.CutPrefix(strings.TrimSpace(line), "--")
+	if !ok {
+		return ""
+	}
+	fields := strings.Fields(strings.ToLower(comment))
+	switch {
+	case len(fields) >= 2 && fields[0] == "+goose" && (fields[1] == "up" || fields[1] == "down"):
+		return fields[1]
+	case len(fields) >= 1 && (fields[0] == "migrate:up" || fields[0] == "migrate:down"):
+		return strings.TrimPrefix(fields[0], "migrate:")
+	}
+	return ""
+}
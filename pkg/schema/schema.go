@@ -0,0 +1,151 @@
+// Package schema provides a catalog of database tables and their columns,
+// read from SQL DDL files, directories of migrations or a JSON catalog,
+// which the analyzer uses to rewrite SELECT * into explicit column lists.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Table is a table of a catalog.
+type Table struct {
+	// Name is the name of the table as declared, possibly schema-qualified
+	// like public.users, without quotes
+	Name string
+	// Columns lists the names of the columns in declaration order
+	Columns []string
+
+	// quoted holds the lower-cased names of the columns declared as quoted
+	// identifiers
+	quoted map[string]bool
+}
+
+// HasColumn reports whether the table has the named column, compared
+// case-insensitively.
+func (t *Table) HasColumn(name string) bool {
+	return t.columnIndex(name) >= 0
+}
+
+// NeedsQuotes reports whether the named column was declared as a quoted
+// identifier that is not all lower case, like "Email", which databases
+// folding unquoted names to lower case only find when quoted.
+func (t *Table) NeedsQuotes(name string) bool {
+	return t.quoted[strings.ToLower(name)] && name != strings.ToLower(name)
+}
+
+// Catalog lists the tables of a database schema. Table names are matched
+// case-insensitively.
+type Catalog struct {
+	tables map[string]*Table
+}
+
+// NewCatalog returns an empty catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{tables: make(map[string]*Table)}
+}
+
+// Add adds table to the catalog, replacing a table of the same name.
+func (c *Catalog) Add(table *Table) {
+	c.tables[strings.ToLower(table.Name)] = table
+}
+
+// Table returns the table with the given name. A schema-qualified name also
+// matches a table declared without schema, and an unqualified name matches
+// the only table of that name in any schema.
+func (c *Catalog) Table(name string) (*Table, bool) {
+	key, ok := c.key(name)
+	if !ok {
+		return nil, false
+	}
+	return c.tables[key], true
+}
+
+// Remove removes the table with the given name, matched like Table.
+func (c *Catalog) Remove(name string) {
+	if key, ok := c.key(name); ok {
+		delete(c.tables, key)
+	}
+}
+
+// key returns the key of the table with the given name in c.tables.
+func (c *Catalog) key(name string) (string, bool) {
+	key := strings.ToLower(name)
+	if _, ok := c.tables[key]; ok {
+		return key, true
+	}
+	if i := strings.LastIndexByte(key, '.'); i >= 0 {
+		_, ok := c.tables[key[i+1:]]
+		return key[i+1:], ok
+	}
+
+	found := ""
+	for qualified := range c.tables {
+		if strings.HasSuffix(qualified, "."+key) {
+			if found != "" {
+				return "", false
+			}
+			found = qualified
+		}
+	}
+	return found, found != ""
+}
+
+// Tables returns the tables of the catalog sorted by name.
+func (c *Catalog) Tables() []*Table {
+	tables := make([]*Table, 0, len(c.tables))
+	for _, table := range c.tables {
+		tables = append(tables, table)
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Name < tables[j].Name })
+	return tables
+}
+
+// Load reads a catalog from path, which is a JSON catalog ending in .json,
+// a file of SQL DDL statements, or a directory of migrations applied in
+// order as described for LoadMigrations.
+func Load(path string) (*Catalog, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return LoadMigrations(path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		catalog, err := ParseJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return catalog, nil
+	}
+	catalog := NewCatalog()
+	catalog.Apply(upMigration(string(data)))
+	return catalog, nil
+}
+
+// ParseJSON reads a catalog mapping table names to their ordered columns:
+//
+//	{"users": ["id", "name", "email"], "public.orders": ["id", "user_id"]}
+func ParseJSON(data []byte) (*Catalog, error) {
+	var tables map[string][]string
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(&tables); err != nil {
+		return nil, err
+	}
+	catalog := NewCatalog()
+	for name, columns := range tables {
+		catalog.Add(&Table{Name: name, Columns: columns})
+	}
+	return catalog, nil
+}
@@ -0,0 +1,203 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	catalog := NewCatalog()
+	catalog.Apply(`
+		-- users of the application
+		CREATE TABLE IF NOT EXISTS users (
+			id BIGSERIAL PRIMARY KEY,
+			"name" VARCHAR(255) NOT NULL,
+			email TEXT UNIQUE,
+			created_at TIMESTAMP DEFAULT now(),
+			CONSTRAINT users_email_check CHECK (email <> '')
+		);
+		CREATE INDEX users_email ON users (email);
+		CREATE TEMPORARY TABLE public.orders (id INT, user_id INT, total NUMERIC(10, 2), PRIMARY KEY (id));
+		CREATE TABLE copy AS SELECT * FROM users;
+	`)
+
+	tests := []struct {
+		name    string
+		columns []string
+	}{
+		{"users", []string{"id", "name", "email", "created_at"}},
+		{"USERS", []string{"id", "name", "email", "created_at"}},
+		{"public.orders", []string{"id", "user_id", "total"}},
+		{"orders", []string{"id", "user_id", "total"}},
+		{"public.users", []string{"id", "name", "email", "created_at"}},
+	}
+	for _, tt := range tests {
+		table, ok := catalog.Table(tt.name)
+		if !ok {
+			t.Errorf("Table(%q) not found", tt.name)
+			continue
+		}
+		if !reflect.DeepEqual(table.Columns, tt.columns) {
+			t.Errorf("Table(%q).Columns = %q, want %q", tt.name, table.Columns, tt.columns)
+		}
+	}
+	if _, ok := catalog.Table("copy"); ok {
+		t.Error("CREATE TABLE ... AS SELECT should not add a table")
+	}
+	if users, _ := catalog.Table("users"); !users.HasColumn("Email") || users.HasColumn("emial") {
+		t.Errorf("HasColumn should match the columns of users case-insensitively, got %q", users.Columns)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write("001_users.sql", "CREATE TABLE users (id INT);")
+	write("002_users.sql", "CREATE TABLE users (id INT, name TEXT);")
+	write("notes.txt", "CREATE TABLE notes (id INT);")
+	jsonPath := write("catalog.json", `{"users": ["id", "email"]}`)
+
+	catalog, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table, ok := catalog.Table("users"); !ok || !reflect.DeepEqual(table.Columns, []string{"id", "name"}) {
+		t.Errorf("Load(%q): users = %+v, want the table of the last migration", dir, table)
+	}
+	if _, ok := catalog.Table("notes"); ok {
+		t.Error("Load should only read .sql files of a directory")
+	}
+
+	catalog, err = Load(jsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table, ok := catalog.Table("users"); !ok || !reflect.DeepEqual(table.Columns, []string{"id", "email"}) {
+		t.Errorf("Load(%q): users = %+v", jsonPath, table)
+	}
+
+	if _, err := Load(write("bad.json", `{"users": "id"}`)); err == nil {
+		t.Error("Load should reject a malformed JSON catalog")
+	}
+}
+
+func TestApplyAlterations(t *testing.T) {
+	catalog := NewCatalog()
+	catalog.Apply(`
+		CREATE TABLE users (id INT, name TEXT, email TEXT);
+		CREATE TABLE IF NOT EXISTS users (id INT);
+		ALTER TABLE users ADD COLUMN created_at TIMESTAMP, ADD CONSTRAINT users_pk PRIMARY KEY (id);
+		ALTER TABLE IF EXISTS ONLY users DROP COLUMN IF EXISTS email CASCADE;
+		ALTER TABLE users RENAME COLUMN name TO full_name;
+		ALTER TABLE users ADD nickname TEXT AFTER id, ADD uuid CHAR(36) FIRST;
+		ALTER TABLE users CHANGE COLUMN nickname handle VARCHAR(32);
+		ALTER TABLE users ADD INDEX users_handle (handle);
+
+		CREATE TABLE orders (id INT, total NUMERIC);
+		ALTER TABLE orders RENAME TO purchases;
+
+		CREATE TABLE logs (id INT);
+		CREATE TABLE events (id INT);
+		DROP TABLE IF EXISTS logs, events;
+
+		CREATE TABLE carts (id INT);
+		RENAME TABLE carts TO baskets;
+
+		ALTER TABLE missing ADD COLUMN id INT;
+	`)
+
+	want := map[string][]string{
+		"users":     {"uuid", "id", "handle", "full_name", "created_at"},
+		"purchases": {"id", "total"},
+		"baskets":   {"id"},
+	}
+	var got []string
+	for _, table := range catalog.Tables() {
+		got = append(got, table.Name)
+		if !reflect.DeepEqual(table.Columns, want[table.Name]) {
+			t.Errorf("%s.Columns = %q, want %q", table.Name, table.Columns, want[table.Name])
+		}
+	}
+	if !reflect.DeepEqual(got, []string{"baskets", "purchases", "users"}) {
+		t.Errorf("tables = %q, want baskets, purchases and users", got)
+	}
+}
+
+func TestNeedsQuotes(t *testing.T) {
+	catalog := NewCatalog()
+	catalog.Apply(`
+		CREATE TABLE contacts (id INT, "Email" TEXT, "phone" TEXT, Name TEXT, "Fax" TEXT, "Old" TEXT);
+		ALTER TABLE contacts ADD COLUMN "Nickname" TEXT;
+		ALTER TABLE contacts RENAME COLUMN "Fax" TO fax;
+		ALTER TABLE contacts RENAME COLUMN phone TO "Phone";
+		ALTER TABLE contacts DROP COLUMN "Old";
+	`)
+	contacts, ok := catalog.Table("contacts")
+	if !ok {
+		t.Fatal("contacts was not created")
+	}
+
+	for column, want := range map[string]bool{
+		"id":       false,
+		"Email":    true,
+		"Name":     false,
+		"fax":      false,
+		"Phone":    true,
+		"Nickname": true,
+	} {
+		if got := contacts.NeedsQuotes(column); got != want {
+			t.Errorf("NeedsQuotes(%q) = %v, want %v", column, got, want)
+		}
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	layouts := map[string]map[string]string{
+		"golang-migrate": {
+			"2_add_email.up.sql":      "ALTER TABLE users ADD COLUMN email TEXT;",
+			"2_add_email.down.sql":    "ALTER TABLE users DROP COLUMN email;",
+			"10_drop_name.up.sql":     "ALTER TABLE users DROP COLUMN name;",
+			"10_drop_name.down.sql":   "ALTER TABLE users ADD COLUMN name TEXT;",
+			"1_create_users.up.sql":   "CREATE TABLE users (id INT, name TEXT);",
+			"1_create_users.down.sql": "DROP TABLE users;",
+			"README.md":               "CREATE TABLE notes (id INT);",
+		},
+		"goose": {
+			"20240101000000_create_users.sql": "-- +goose Up\nCREATE TABLE users (id INT, name TEXT);\n\n-- +goose Down\nDROP TABLE users;\n",
+			"20240102000000_add_email.sql": "-- +goose Up\n-- +goose StatementBegin\nALTER TABLE users ADD COLUMN email TEXT;\n-- +goose StatementEnd\n" +
+				"-- +goose Down\nALTER TABLE users DROP COLUMN email;\n",
+			"20240103000000_drop_name.sql": "-- +goose Up\nALTER TABLE users DROP COLUMN name;\n-- +goose Down\nALTER TABLE users ADD COLUMN name TEXT;\n",
+		},
+		"atlas": {
+			"20240101000000_init.sql":  "CREATE TABLE users (id INT, name TEXT);",
+			"20240102000000_email.sql": "ALTER TABLE users ADD COLUMN email TEXT, DROP COLUMN name;",
+			"atlas.sum":                "h1:...",
+		},
+	}
+
+	for layout, files := range layouts {
+		t.Run(layout, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, data := range files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			catalog, err := Load(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tables := catalog.Tables(); len(tables) != 1 || !reflect.DeepEqual(tables[0].Columns, []string{"id", "email"}) {
+				t.Errorf("Load(%s) = %+v, want users with id and email", layout, tables)
+			}
+		})
+	}
+}